@@ -0,0 +1,63 @@
+package list
+
+import "errors"
+
+// ErrNoCurrent is returned by Cursor.Delete when the cursor is not positioned on an element.
+var ErrNoCurrent = errors.New("list: cursor has no current element")
+
+// Cursor walks a List and allows removing the current element without
+// skipping or revisiting its neighbours.
+type Cursor struct {
+	list List
+	cur  int
+	next int
+}
+
+// Iterator returns a Cursor positioned before the first element.
+func (d List) Iterator() *Cursor {
+	return &Cursor{list: d, cur: -1}
+}
+
+// Next advances to the next element and reports whether there is one.
+func (c *Cursor) Next() bool {
+	if c.next >= len(*c.list.value) {
+		c.cur = -1
+		return false
+	}
+	c.cur = c.next
+	c.next++
+	return true
+}
+
+// Value returns the current element, or "" if there is none.
+func (c *Cursor) Value() string {
+	if c.cur < 0 {
+		return ""
+	}
+	return (*c.list.value)[c.cur]
+}
+
+// Index returns the position of the current element, or -1 if there is none.
+func (c *Cursor) Index() int {
+	return c.cur
+}
+
+// Delete removes the current element from the list. The following call to
+// Next moves to the element that came after it.
+func (c *Cursor) Delete() error {
+	if c.cur < 0 {
+		return ErrNoCurrent
+	}
+	if err := c.list.mutable(); err != nil {
+		return err
+	}
+
+	idx := c.cur
+	v := *c.list.value
+	item := v[idx]
+	*c.list.value = append(v[:idx], v[idx+1:]...)
+	c.next = idx
+	c.cur = -1
+	c.list.notify(OpRemove, idx, item)
+	return nil
+}
@@ -0,0 +1,69 @@
+package list
+
+import "testing"
+
+func TestList_Iterator(t *testing.T) {
+	l := NewList([]string{"keep1", "del", "del", "keep2", "del", "keep3", "del", "del"})
+
+	var visited []string
+	it := l.Iterator()
+	for it.Next() {
+		visited = append(visited, it.Value())
+		if it.Value() == "del" {
+			if err := it.Delete(); err != nil {
+				t.Fatalf("Delete() at %d: %v", it.Index(), err)
+			}
+		}
+	}
+
+	if !l.EqualSlice([]string{"keep1", "keep2", "keep3"}) {
+		t.Errorf("after deleting marked elements list = %v, want [keep1 keep2 keep3]", l)
+	}
+	if len(visited) != 8 {
+		t.Errorf("visited %d elements, want 8: %v", len(visited), visited)
+	}
+}
+
+func TestCursor_Index(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+	it := l.Iterator()
+
+	if it.Index() != -1 {
+		t.Errorf("Index() before Next = %d, want -1", it.Index())
+	}
+	it.Next()
+	it.Next()
+	if it.Index() != 1 || it.Value() != "b" {
+		t.Errorf("after two Next calls got (%d, %q), want (1, \"b\")", it.Index(), it.Value())
+	}
+	if err := it.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next() || it.Index() != 1 || it.Value() != "c" {
+		t.Errorf("Next after Delete got (%d, %q), want (1, \"c\")", it.Index(), it.Value())
+	}
+}
+
+func TestCursor_Delete(t *testing.T) {
+	l := NewList([]string{"a", "b"})
+	it := l.Iterator()
+
+	if err := it.Delete(); err != ErrNoCurrent {
+		t.Errorf("Delete() before Next = %v, want ErrNoCurrent", err)
+	}
+	it.Next()
+	if err := it.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if err := it.Delete(); err != ErrNoCurrent {
+		t.Errorf("second Delete() = %v, want ErrNoCurrent", err)
+	}
+	for it.Next() {
+	}
+	if err := it.Delete(); err != ErrNoCurrent {
+		t.Errorf("Delete() after exhaustion = %v, want ErrNoCurrent", err)
+	}
+	if !l.EqualSlice([]string{"b"}) {
+		t.Errorf("list = %v, want [b]", l)
+	}
+}
@@ -0,0 +1,215 @@
+package list
+
+import "github.com/spf13/cast"
+
+// ElasticList is a double-ended list backed by a circular buffer, so adding
+// or removing at either end is O(1) amortized where List is O(n) at the
+// front. It has List's element operations, with the same semantics; use
+// ToList for the rest, such as sorting or hooks.
+type ElasticList struct {
+	buf  []string
+	head int
+	n    int
+}
+
+// NewElasticList converts va as NewList does.
+func NewElasticList(va interface{}) *ElasticList {
+	val := cast.ToStringSlice(va)
+	return &ElasticList{buf: append([]string(nil), val...), n: len(val)}
+}
+
+// Length returns the number of elements.
+func (e *ElasticList) Length() int {
+	return e.n
+}
+
+// Append adds value at the back.
+func (e *ElasticList) Append(value interface{}) *ElasticList {
+	e.grow()
+	e.buf[e.at(e.n)] = cast.ToString(value)
+	e.n++
+	return e
+}
+
+// Prepend adds value at the front.
+func (e *ElasticList) Prepend(value interface{}) *ElasticList {
+	e.grow()
+	e.head = e.at(len(e.buf) - 1)
+	e.buf[e.head] = cast.ToString(value)
+	e.n++
+	return e
+}
+
+// PopFront removes and returns the first element, or ("", false) if there is none.
+func (e *ElasticList) PopFront() (string, bool) {
+	if e.n == 0 {
+		return "", false
+	}
+	v := e.buf[e.head]
+	e.buf[e.head] = ""
+	e.head = e.at(1)
+	e.n--
+	return v, true
+}
+
+// PopBack removes and returns the last element, or ("", false) if there is none.
+func (e *ElasticList) PopBack() (string, bool) {
+	if e.n == 0 {
+		return "", false
+	}
+	i := e.at(e.n - 1)
+	v := e.buf[i]
+	e.buf[i] = ""
+	e.n--
+	return v, true
+}
+
+// Extend appends every element of sub.
+func (e *ElasticList) Extend(sub interface{}) *ElasticList {
+	for _, v := range cast.ToStringSlice(sub) {
+		e.Append(v)
+	}
+	return e
+}
+
+// Insert adds value before the element at idx, in O(n - idx). Like
+// List.Insert it does nothing if idx is outside [0, Length()].
+func (e *ElasticList) Insert(idx int, value interface{}) *ElasticList {
+	if idx < 0 || idx > e.n {
+		return e
+	}
+	e.grow()
+	e.n++
+	for i := e.n - 1; i > idx; i-- {
+		e.buf[e.at(i)] = e.buf[e.at(i-1)]
+	}
+	e.buf[e.at(idx)] = cast.ToString(value)
+	return e
+}
+
+// Pop removes the element at idx, counting from the end if idx is negative.
+// Like List.Pop it ignores an index out of range.
+func (e *ElasticList) Pop(idx int) *ElasticList {
+	if idx < 0 {
+		idx += e.n
+	}
+	if idx < 0 || idx >= e.n {
+		return e
+	}
+	for i := idx; i < e.n-1; i++ {
+		e.buf[e.at(i)] = e.buf[e.at(i+1)]
+	}
+	e.buf[e.at(e.n-1)] = ""
+	e.n--
+	return e
+}
+
+// Remove removes every element equal to value.
+func (e *ElasticList) Remove(value interface{}) *ElasticList {
+	s := cast.ToString(value)
+	kept := 0
+	for i := 0; i < e.n; i++ {
+		if v := e.buf[e.at(i)]; v != s {
+			e.buf[e.at(kept)] = v
+			kept++
+		}
+	}
+	for i := kept; i < e.n; i++ {
+		e.buf[e.at(i)] = ""
+	}
+	e.n = kept
+	return e
+}
+
+// Index returns the position of the first element equal to sub, or -1.
+func (e *ElasticList) Index(sub interface{}) int {
+	s := cast.ToString(sub)
+	for i := 0; i < e.n; i++ {
+		if e.buf[e.at(i)] == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func (e *ElasticList) In(sub interface{}) bool {
+	return e.Index(sub) >= 0
+}
+
+// Count returns how many elements equal value.
+func (e *ElasticList) Count(value interface{}) (count int) {
+	s := cast.ToString(value)
+	for i := 0; i < e.n; i++ {
+		if e.buf[e.at(i)] == s {
+			count++
+		}
+	}
+	return
+}
+
+// IsEmpty reports whether the list has no elements.
+func (e *ElasticList) IsEmpty() bool {
+	return e.n == 0
+}
+
+// EqualSlice reports whether the list holds exactly the elements of other, in order.
+func (e *ElasticList) EqualSlice(other []string) bool {
+	return EqualSlices(e.StringSlice(), other)
+}
+
+// StringSlice returns a copy of the elements, front to back.
+func (e *ElasticList) StringSlice() []string {
+	val := make([]string, e.n)
+	e.copyTo(val)
+	return val
+}
+
+// Peek returns the element at idx, or ("", false) if idx is out of range.
+func (e *ElasticList) Peek(idx int) (string, bool) {
+	if idx < 0 || idx >= e.n {
+		return "", false
+	}
+	return e.buf[e.at(idx)], true
+}
+
+// ToList copies the elements, front to back, into a new List.
+func (e *ElasticList) ToList() List {
+	return newList(e.StringSlice())
+}
+
+func (e *ElasticList) String() string {
+	return e.ToList().String()
+}
+
+// at maps a logical index, counted from the front, to a position in buf.
+func (e *ElasticList) at(idx int) int {
+	return (e.head + idx) % len(e.buf)
+}
+
+// grow makes room for one more element, doubling the buffer when it is full.
+func (e *ElasticList) grow() {
+	if e.n < len(e.buf) {
+		return
+	}
+	size := 2 * len(e.buf)
+	if size == 0 {
+		size = 4
+	}
+	buf := make([]string, size)
+	e.copyTo(buf)
+	e.buf, e.head = buf, 0
+}
+
+// copyTo copies the elements in order into dst, which must hold e.n of them.
+func (e *ElasticList) copyTo(dst []string) {
+	if e.n == 0 {
+		return
+	}
+	end := e.head + e.n
+	if end <= len(e.buf) {
+		copy(dst, e.buf[e.head:end])
+		return
+	}
+	k := copy(dst, e.buf[e.head:])
+	copy(dst[k:], e.buf[:end-len(e.buf)])
+}
@@ -0,0 +1,101 @@
+package list
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestElasticList(t *testing.T) {
+	e := NewElasticList([]string{"b", "c"})
+	e.Prepend("a").Append("d").Prepend(0)
+
+	if got := e.ToList(); !got.EqualSlice([]string{"0", "a", "b", "c", "d"}) || e.Length() != 5 {
+		t.Fatalf("ToList() = %v, Length() = %d", got, e.Length())
+	}
+	if v, ok := e.PopFront(); v != "0" || !ok {
+		t.Errorf("PopFront() = (%q, %v)", v, ok)
+	}
+	if v, ok := e.PopBack(); v != "d" || !ok {
+		t.Errorf("PopBack() = (%q, %v)", v, ok)
+	}
+	if v, ok := e.Peek(1); v != "b" || !ok {
+		t.Errorf("Peek(1) = (%q, %v)", v, ok)
+	}
+	if _, ok := e.Peek(3); ok {
+		t.Errorf("Peek(3) past the end succeeded")
+	}
+	if e.String() != "[a b c]" {
+		t.Errorf("String() = %s", e)
+	}
+
+	for e.Length() > 0 {
+		e.PopBack()
+	}
+	if _, ok := e.PopFront(); ok {
+		t.Errorf("PopFront() on empty list succeeded")
+	}
+	if _, ok := e.PopBack(); ok {
+		t.Errorf("PopBack() on empty list succeeded")
+	}
+}
+
+func TestElasticList_ListOps(t *testing.T) {
+	e := NewElasticList([]string{"b", "c"})
+	// Wrap the buffer so the middle operations cross its end.
+	e.Prepend("a").Extend([]string{"x", "d", "x"})
+
+	e.Insert(2, "ins").Insert(0, "first").Insert(e.Length(), "last").Insert(99, "no")
+	if !e.EqualSlice([]string{"first", "a", "b", "ins", "c", "x", "d", "x", "last"}) {
+		t.Fatalf("after Insert = %v", e)
+	}
+	if e.Count("x") != 2 || e.Index("x") != 5 || !e.In("d") || e.In("no") {
+		t.Errorf("Count, Index or In wrong on %v", e)
+	}
+
+	e.Remove("x").Pop(0).Pop(-1).Pop(2).Pop(99)
+	if !e.EqualSlice([]string{"a", "b", "c", "d"}) {
+		t.Errorf("after Remove and Pop = %v, want [a b c d]", e)
+	}
+	if v, ok := e.PopBack(); v != "d" || !ok {
+		t.Errorf("PopBack() after Pop = (%q, %v)", v, ok)
+	}
+
+	// Same operations on a List give the same result.
+	l := NewList([]string{"a", "x", "b"})
+	l.Insert(1, "i").Remove("x").Pop(-1)
+	got := NewElasticList([]string{"a", "x", "b"}).Insert(1, "i").Remove("x").Pop(-1)
+	if !got.EqualSlice(*l.value) {
+		t.Errorf("ElasticList = %v, List = %v", got, l)
+	}
+	if NewElasticList(nil).IsEmpty() != true || got.IsEmpty() {
+		t.Errorf("IsEmpty() wrong")
+	}
+}
+
+// TestElasticList_wrap checks that order survives the buffer wrapping around
+// and growing while wrapped, against a plain slice as the model.
+func TestElasticList_wrap(t *testing.T) {
+	e := NewElasticList(nil)
+	var model []string
+
+	for i := 0; i < 200; i++ {
+		v := strconv.Itoa(i)
+		switch i % 5 {
+		case 0, 1:
+			e.Append(v)
+			model = append(model, v)
+		case 2:
+			e.Prepend(v)
+			model = append([]string{v}, model...)
+		case 3:
+			e.PopFront()
+			model = model[1:]
+		case 4:
+			e.Prepend(v)
+			model = append([]string{v}, model...)
+		}
+		if !e.ToList().EqualSlice(model) {
+			t.Fatalf("after step %d: %v, want %v", i, e.ToList(), model)
+		}
+	}
+}
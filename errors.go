@@ -0,0 +1,68 @@
+package list
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ListError describes a failed operation. Errors the package detects itself
+// are a *ListError or one of the sentinels ErrFrozen, ErrReentrant,
+// ErrNoCurrent and ErrCapacity, which callers compare against directly.
+// Errors that come from elsewhere pass through unchanged: those returned by
+// callbacks, ctx.Err() from ForEachCtx, and I/O errors from the io.Writer or
+// io.Reader given to WriteTo, WriteToSep, Fprintf and ReadFrom. Methods
+// documented to panic do so with a *ListError, or with a sentinel from guard.
+type ListError struct {
+	Op  string // method that failed, such as "Percentile"
+	Msg string // what went wrong
+	Err error  // underlying error, if any
+}
+
+func (e *ListError) Error() string {
+	s := "list: " + e.Op + ": " + e.Msg
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+	return s
+}
+
+// Unwrap returns the underlying error.
+func (e *ListError) Unwrap() error {
+	return e.Err
+}
+
+// errorf returns a *ListError for op with a formatted message.
+func errorf(op, format string, args ...interface{}) error {
+	return &ListError{Op: op, Msg: fmt.Sprintf(format, args...)}
+}
+
+// wrapError returns a *ListError for op caused by err.
+func wrapError(op string, err error, format string, args ...interface{}) error {
+	return &ListError{Op: op, Msg: fmt.Sprintf(format, args...), Err: err}
+}
+
+// maxValidationShown caps how many failures ValidationError.Error lists.
+const maxValidationShown = 5
+
+// ValidationError records every element that failed Validate, in order. It is
+// the Err of the *ListError that Validate returns.
+type ValidationError struct {
+	Indices []int
+	Values  []string
+}
+
+func (e *ValidationError) Error() string {
+	shown := len(e.Indices)
+	if shown > maxValidationShown {
+		shown = maxValidationShown
+	}
+	parts := make([]string, shown)
+	for i := range parts {
+		parts[i] = fmt.Sprintf("%d %q", e.Indices[i], e.Values[i])
+	}
+	s := "elements " + strings.Join(parts, ", ")
+	if rest := len(e.Indices) - shown; rest > 0 {
+		s += fmt.Sprintf(" and %d more", rest)
+	}
+	return s
+}
@@ -0,0 +1,51 @@
+package list
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestListError(t *testing.T) {
+	_, err := NewList([]string{"1s", "soon"}).ParseDurations()
+
+	var le *ListError
+	if !errors.As(err, &le) {
+		t.Fatalf("ParseDurations() error %T is not a *ListError", err)
+	}
+	if le.Op != "ParseDurations" || le.Msg != "element 1" || le.Err == nil {
+		t.Errorf("ListError = %+v", le)
+	}
+	if want := "list: ParseDurations: element 1: " + le.Err.Error(); err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if errors.Unwrap(err) != le.Err {
+		t.Errorf("Unwrap() = %v, want %v", errors.Unwrap(err), le.Err)
+	}
+	if _, parseErr := time.ParseDuration("soon"); le.Err.Error() != parseErr.Error() {
+		t.Errorf("wrapped %v, want %v", le.Err, parseErr)
+	}
+
+	_, err = NilList(nil).Percentile(50)
+	if err == nil || err.Error() != "list: Percentile: empty list" {
+		t.Errorf("Percentile() on empty list error = %v", err)
+	}
+}
+
+func TestListError_panics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"MustMin":   func() { NilList(nil).MustMin() },
+		"Cycle":     func() { NilList(nil).Cycle(1) },
+		"Chunk":     func() { NilList(nil).Chunk(0) },
+		"ChunkIter": func() { NilList(nil).ChunkIter(-1) },
+	} {
+		func() {
+			defer func() {
+				if _, ok := recover().(*ListError); !ok {
+					t.Errorf("%s did not panic with a *ListError", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,35 @@
+package list
+
+// StringIterator is a restartable, forward-only sequence of strings. Code
+// written against it does not depend on where the elements come from. It is
+// unrelated to List.Iterator, which returns a *Cursor that can delete.
+type StringIterator interface {
+	// Next returns the next element, or ("", false) once the sequence is
+	// exhausted.
+	Next() (string, bool)
+	// Reset moves back to the start of the sequence.
+	Reset()
+}
+
+// NewIterator returns a StringIterator over the list. It reads the list as it is at
+// each call to Next, so elements appended during iteration are visited.
+func (d List) NewIterator() StringIterator {
+	return &listIterator{list: d}
+}
+
+type listIterator struct {
+	list List
+	pos  int
+}
+
+func (it *listIterator) Next() (string, bool) {
+	if it.pos >= len(*it.list.value) {
+		return "", false
+	}
+	it.pos++
+	return (*it.list.value)[it.pos-1], true
+}
+
+func (it *listIterator) Reset() {
+	it.pos = 0
+}
@@ -0,0 +1,37 @@
+package list
+
+import "testing"
+
+// drain collects what is left in it.
+func drain(it StringIterator) []string {
+	var out []string
+	for v, ok := it.Next(); ok; v, ok = it.Next() {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestList_NewIterator(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+	it := l.NewIterator()
+
+	if v, ok := it.Next(); v != "a" || !ok {
+		t.Fatalf("Next() = (%q, %v), want (\"a\", true)", v, ok)
+	}
+	if got := drain(it); !NewList(got).EqualSlice([]string{"b", "c"}) {
+		t.Errorf("remaining = %v, want [b c]", got)
+	}
+	if v, ok := it.Next(); v != "" || ok {
+		t.Errorf("Next() when exhausted = (%q, %v), want (\"\", false)", v, ok)
+	}
+
+	it.Reset()
+	l.Append("d")
+	if got := drain(it); !NewList(got).EqualSlice([]string{"a", "b", "c", "d"}) {
+		t.Errorf("after Reset = %v, want [a b c d]", got)
+	}
+
+	if got := drain(NilList(nil).NewIterator()); len(got) != 0 {
+		t.Errorf("empty list yielded %v", got)
+	}
+}
@@ -0,0 +1,152 @@
+package list
+
+import (
+	"github.com/spf13/cast"
+	"sort"
+)
+
+// LazyList is a read-only sequence whose elements are produced on demand by a
+// generator, so prefixes of long or infinite sequences never get materialized.
+type LazyList struct {
+	gen func(yield func(string) bool)
+}
+
+// NewLazyList wraps gen, which must call yield for each element in order and
+// stop as soon as yield returns false.
+func NewLazyList(gen func(yield func(string) bool)) LazyList {
+	return LazyList{gen: gen}
+}
+
+// Lazy returns a LazyList over l. Nothing is read from l until the lazy list
+// is consumed, so a chain of Filter, Map and Sorted built on it runs in a
+// single pass over whatever l holds at that point.
+func Lazy(l List) LazyList {
+	return NewLazyList(func(yield func(string) bool) {
+		for _, v := range *l.value {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Each calls fn for every element until fn returns false.
+func (z LazyList) Each(fn func(string) bool) {
+	done := false
+	z.gen(func(v string) bool {
+		if done {
+			return false
+		}
+		if !fn(v) {
+			done = true
+		}
+		return !done
+	})
+}
+
+// Take materializes at most the first n elements.
+func (z LazyList) Take(n int) List {
+	val := make([]string, 0)
+	if n <= 0 {
+		return newList(val)
+	}
+	z.Each(func(v string) bool {
+		val = append(val, v)
+		return len(val) < n
+	})
+	return newList(val)
+}
+
+// First returns the first element, or ("", false) if there is none.
+func (z LazyList) First() (first string, ok bool) {
+	z.Each(func(v string) bool {
+		first, ok = v, true
+		return false
+	})
+	return
+}
+
+// Index returns the position of the first element equal to sub, or -1.
+// Like In it stops at the first match, but never returns on an infinite
+// sequence without one.
+func (z LazyList) Index(sub interface{}) int {
+	s := cast.ToString(sub)
+	idx, i := -1, 0
+	z.Each(func(v string) bool {
+		if v == s {
+			idx = i
+			return false
+		}
+		i++
+		return true
+	})
+	return idx
+}
+
+func (z LazyList) In(sub interface{}) bool {
+	return z.Index(sub) >= 0
+}
+
+// Filter returns a lazy list of the elements for which pred returns true.
+func (z LazyList) Filter(pred func(string) bool) LazyList {
+	return NewLazyList(func(yield func(string) bool) {
+		z.Each(func(v string) bool {
+			return !pred(v) || yield(v)
+		})
+	})
+}
+
+// Map returns a lazy list with fn applied to every element.
+func (z LazyList) Map(fn func(string) string) LazyList {
+	return NewLazyList(func(yield func(string) bool) {
+		z.Each(func(v string) bool {
+			return yield(fn(v))
+		})
+	})
+}
+
+// Materialize collects every element into a List. It never returns for an
+// infinite sequence.
+func (z LazyList) Materialize() List {
+	val := make([]string, 0)
+	z.Each(func(v string) bool {
+		val = append(val, v)
+		return true
+	})
+	return newList(val)
+}
+
+// Collect is Materialize under the name used by Stream.
+func (z LazyList) Collect() List {
+	return z.Materialize()
+}
+
+// Length materializes the sequence and returns its length.
+func (z LazyList) Length() int {
+	n := 0
+	z.Each(func(string) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// Sort materializes the sequence and returns it sorted.
+func (z LazyList) Sort() List {
+	l := z.Materialize()
+	sort.Strings(*l.value)
+	return l
+}
+
+// Sorted returns a lazy list of the elements in sorted order. Sorting needs
+// every element, so the sequence is materialized once the result is consumed,
+// not when Sorted is called. Stages after it stay lazy.
+func (z LazyList) Sorted() LazyList {
+	return NewLazyList(func(yield func(string) bool) {
+		for _, v := range *z.Sort().value {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package list
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// naturals yields "0", "1", "2", ... forever and counts how many it produced.
+func naturals(produced *int) LazyList {
+	return NewLazyList(func(yield func(string) bool) {
+		for i := 0; ; i++ {
+			*produced++
+			if !yield(strconv.Itoa(i)) {
+				return
+			}
+		}
+	})
+}
+
+func TestLazyList_Take(t *testing.T) {
+	produced := 0
+	got := naturals(&produced).Take(5)
+
+	if !got.EqualSlice([]string{"0", "1", "2", "3", "4"}) {
+		t.Errorf("Take(5) = %v, want [0 1 2 3 4]", got)
+	}
+	if produced != 5 {
+		t.Errorf("Take(5) produced %d elements, want 5", produced)
+	}
+	if naturals(&produced).Take(0).Length() != 0 {
+		t.Errorf("Take(0) is not empty")
+	}
+}
+
+func TestLazyList_First(t *testing.T) {
+	produced := 0
+	if v, ok := naturals(&produced).First(); !ok || v != "0" {
+		t.Errorf("First() = (%q, %v), want (\"0\", true)", v, ok)
+	}
+
+	empty := NewLazyList(func(func(string) bool) {})
+	if _, ok := empty.First(); ok {
+		t.Errorf("First() on empty sequence returned ok = true")
+	}
+}
+
+func TestLazyList_Index(t *testing.T) {
+	produced := 0
+	if idx := naturals(&produced).Index(42); idx != 42 {
+		t.Errorf("Index(42) = %d, want 42", idx)
+	}
+	if produced != 43 {
+		t.Errorf("Index(42) produced %d elements, want 43", produced)
+	}
+	if !naturals(&produced).In("7") {
+		t.Errorf("In(\"7\") = false")
+	}
+}
+
+func TestLazyList_Filter(t *testing.T) {
+	produced := 0
+	even := naturals(&produced).Filter(func(s string) bool {
+		n, _ := strconv.Atoi(s)
+		return n%2 == 0
+	})
+
+	if got := even.Take(3); !got.EqualSlice([]string{"0", "2", "4"}) {
+		t.Errorf("Filter().Take(3) = %v, want [0 2 4]", got)
+	}
+}
+
+func TestLazyList_Map(t *testing.T) {
+	produced := 0
+	got := naturals(&produced).Map(func(s string) string { return "n" + s }).Take(2)
+
+	if !got.EqualSlice([]string{"n0", "n1"}) {
+		t.Errorf("Map().Take(2) = %v, want [n0 n1]", got)
+	}
+}
+
+func TestLazyList_Materialize(t *testing.T) {
+	finite := NewLazyList(func(yield func(string) bool) {
+		for _, v := range []string{"c", "a", "b"} {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+
+	if got := finite.Materialize(); !got.EqualSlice([]string{"c", "a", "b"}) {
+		t.Errorf("Materialize() = %v, want [c a b]", got)
+	}
+	if got := finite.Sort(); !got.EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("Sort() = %v, want [a b c]", got)
+	}
+	if finite.Length() != 3 {
+		t.Errorf("Length() = %d, want 3", finite.Length())
+	}
+}
+
+func TestLazyList_Each(t *testing.T) {
+	// A generator that ignores yield's result must still not reach fn again.
+	rude := NewLazyList(func(yield func(string) bool) {
+		yield("a")
+		yield("b")
+		yield("c")
+	})
+
+	var seen []string
+	rude.Each(func(v string) bool {
+		seen = append(seen, v)
+		return v != "b"
+	})
+	if !NewList(seen).EqualSlice([]string{"a", "b"}) {
+		t.Errorf("Each() saw %v, want [a b]", seen)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	l := NewList([]string{"3", "10", "x", "2"})
+	mapped := 0
+	z := Lazy(l).
+		Filter(func(v string) bool { return v != "x" }).
+		Map(func(v string) string { mapped++; return "n" + v }).
+		Sorted().
+		Map(strings.ToUpper)
+
+	if mapped != 0 {
+		t.Fatalf("building the chain ran Map %d times", mapped)
+	}
+	l.Append("1")
+	if got := z.Collect(); !got.EqualSlice([]string{"N1", "N10", "N2", "N3"}) {
+		t.Errorf("Collect() = %v, want [N1 N10 N2 N3]", got)
+	}
+	if mapped != 4 {
+		t.Errorf("Map ran %d times, want 4", mapped)
+	}
+	if !l.EqualSlice([]string{"3", "10", "x", "2", "1"}) {
+		t.Errorf("Collect() modified the source: %v", l)
+	}
+}
@@ -0,0 +1,117 @@
+package list
+
+import (
+	"errors"
+	"github.com/spf13/cast"
+)
+
+// ErrCapacity is returned when an insert would take a LimitedList past its capacity.
+var ErrCapacity = errors.New("list: capacity exceeded")
+
+// LimitedList is a list that refuses inserts beyond maxCap elements. An
+// insert that does not fit is rejected as a whole. The underlying List is not
+// exposed, so every way of adding elements goes through the capacity check;
+// use ToList for the rest of the List API.
+type LimitedList struct {
+	list   List
+	maxCap int
+}
+
+// NewLimitedList returns an empty list that holds at most maxCap elements.
+func NewLimitedList(maxCap int) *LimitedList {
+	return &LimitedList{list: NewList([]string{}), maxCap: maxCap}
+}
+
+// Cap returns the maximum number of elements.
+func (l *LimitedList) Cap() int {
+	return l.maxCap
+}
+
+// Length returns the number of elements.
+func (l *LimitedList) Length() int {
+	return l.list.Length()
+}
+
+// Append adds value at the end.
+func (l *LimitedList) Append(value interface{}) error {
+	if err := l.fits(1); err != nil {
+		return err
+	}
+	l.list.Append(value)
+	return nil
+}
+
+// Prepend inserts value at the front.
+func (l *LimitedList) Prepend(value interface{}) error {
+	return l.Insert(0, value)
+}
+
+// Insert adds value at idx, which must be within [0, Length()].
+func (l *LimitedList) Insert(idx int, value interface{}) error {
+	if n := l.list.Length(); idx < 0 || idx > n {
+		return errorf("Insert", "index %d out of range for length %d", idx, n)
+	}
+	if err := l.fits(1); err != nil {
+		return err
+	}
+	l.list.Insert(idx, value)
+	return nil
+}
+
+// Extend appends all elements of sub, or none if they do not fit.
+func (l *LimitedList) Extend(sub interface{}) error {
+	subs := cast.ToStringSlice(sub)
+	if err := l.fits(len(subs)); err != nil {
+		return err
+	}
+	l.list.Extend(subs)
+	return nil
+}
+
+// AppendList appends all elements of other, or none if they do not fit.
+func (l *LimitedList) AppendList(other List) error {
+	if err := l.fits(other.Length()); err != nil {
+		return err
+	}
+	l.list.AppendList(other)
+	return nil
+}
+
+// Pop removes the element at idx, as List.Pop does.
+func (l *LimitedList) Pop(idx int) {
+	l.list.Pop(idx)
+}
+
+// Remove removes every element equal to value.
+func (l *LimitedList) Remove(value interface{}) {
+	l.list.Remove(value)
+}
+
+// Peek returns the element at idx, or ("", false) if idx is out of range.
+func (l *LimitedList) Peek(idx int) (string, bool) {
+	return l.list.Peek(idx)
+}
+
+func (l *LimitedList) In(sub interface{}) bool {
+	return l.list.In(sub)
+}
+
+func (l *LimitedList) Index(sub interface{}) int {
+	return l.list.Index(sub)
+}
+
+// ToList returns a copy of the elements as an unlimited List.
+func (l *LimitedList) ToList() List {
+	return l.list.Copy()
+}
+
+func (l *LimitedList) String() string {
+	return l.list.String()
+}
+
+func (l *LimitedList) fits(n int) error {
+	if l.list.Length()+n > l.maxCap {
+		return ErrCapacity
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package list
+
+import "testing"
+
+func TestNewLimitedList(t *testing.T) {
+	l := NewLimitedList(3)
+
+	if l.Cap() != 3 || l.Length() != 0 {
+		t.Fatalf("NewLimitedList(3) = cap %d, len %d", l.Cap(), l.Length())
+	}
+	if err := l.Append("b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Prepend("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Insert(2, "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	full := map[string]func() error{
+		"Append":     func() error { return l.Append("x") },
+		"Prepend":    func() error { return l.Prepend("x") },
+		"Insert":     func() error { return l.Insert(1, "x") },
+		"Extend":     func() error { return l.Extend([]string{"x"}) },
+		"AppendList": func() error { return l.AppendList(NewList([]string{"x"})) },
+	}
+	for name, fn := range full {
+		if err := fn(); err != ErrCapacity {
+			t.Errorf("%s on full list = %v, want ErrCapacity", name, err)
+		}
+	}
+	if !l.ToList().EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("list = %v, want [a b c]", l)
+	}
+}
+
+func TestLimitedList_Extend(t *testing.T) {
+	l := NewLimitedList(3)
+
+	if err := l.Extend([]string{"a", "b", "c", "d"}); err != ErrCapacity {
+		t.Errorf("Extend() past capacity = %v, want ErrCapacity", err)
+	}
+	if l.Length() != 0 {
+		t.Errorf("rejected Extend() added elements: %v", l)
+	}
+	if err := l.Extend([]string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AppendList(NewList([]string{"c", "d"})); err != ErrCapacity {
+		t.Errorf("AppendList() past capacity = %v, want ErrCapacity", err)
+	}
+	if err := l.AppendList(NewList([]string{"c"})); err != nil || !l.ToList().EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("AppendList() = %v, list %v", err, l)
+	}
+
+	l.Pop(0)
+	if err := l.Append("d"); err != nil {
+		t.Errorf("Append() after Pop freed a slot = %v", err)
+	}
+}
+
+func TestLimitedList_Insert(t *testing.T) {
+	l := NewLimitedList(5)
+	l.Extend([]string{"a", "b"})
+
+	for _, idx := range []int{-1, 3} {
+		if err := l.Insert(idx, "x"); err == nil {
+			t.Errorf("Insert(%d) on a list of 2 succeeded", idx)
+		}
+	}
+	if err := l.Insert(2, "c"); err != nil || !l.ToList().EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("Insert(2) = %v, list %v", err, l)
+	}
+}
+
+func TestLimitedList_ToList(t *testing.T) {
+	l := NewLimitedList(2)
+	l.Extend([]string{"a", "b"})
+
+	// Growing the copy must not grow the limited list.
+	out := l.ToList()
+	out.InsertSorted("c")
+	out.Extend([]string{"d"})
+	if l.Length() != 2 {
+		t.Errorf("changing ToList() grew the limited list to %v", l)
+	}
+}
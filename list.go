@@ -1,10 +1,26 @@
 package list
 
 import (
+	"bufio"
+	"context"
 	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/spf13/cast"
+	"io"
+	"math"
+	"math/bits"
+	"math/rand"
+	"reflect"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 )
 
 // 	包 list 用来解决 go 中 slice 切片函数操作方法过少的问题.
@@ -12,26 +28,322 @@ import (
 // 	通过实现 python 中 pop remove 等方法来提高可用性
 //	Improve usability by implementing methods like pop remove in python
 type List struct {
-	value  *[]string
-	length int
+	value *[]string
+	state *listState
+}
+
+// listState is shared by every copy of the same List value.
+type listState struct {
+	snapshots []snapshot
+	nextToken int
+	hooks     []func(op string, idx int, value string)
+	firing    bool
+	frozen    bool
+	// cachedLen is the length plus one, or 0 once a mutation has
+	// invalidated it. It is accessed atomically so concurrent readers can
+	// call CachedLength.
+	cachedLen int64
+}
+
+type snapshot struct {
+	token int
+	value []string
+}
+
+func newList(val []string) List {
+	return List{
+		value: &val,
+		state: new(listState),
+	}
 }
 
 // NewList converts a interface to List.
 func NewList(va interface{}) List {
+	return newList(cast.ToStringSlice(va))
+}
+
+// NewListReflect is a stricter NewList for element types cast does not know.
+// Any slice or array is accepted and each element is formatted with fmt.Sprint,
+// so errors use Error() and fmt.Stringers use String(). Any other value becomes
+// a one-element list, and nil an empty one. Funcs, channels and unsafe
+// pointers are rejected.
+func NewListReflect(va interface{}) (List, error) {
+	if va == nil {
+		return newList([]string{}), nil
+	}
+
+	rv := reflect.ValueOf(va)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		val := make([]string, rv.Len())
+		for i := range val {
+			val[i] = fmt.Sprint(rv.Index(i).Interface())
+		}
+		return newList(val), nil
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return List{}, errorf("NewListReflect", "cannot build a list from %T", va)
+	}
+	return newList([]string{fmt.Sprint(va)}), nil
+}
+
+// NewListFromJSON parses a JSON array of strings, numbers and bools, such as
+// ["a", 1, true]. Numbers keep their literal text. Objects, nested arrays and
+// null are errors.
+func NewListFromJSON(s string) (List, error) {
+	// Decoding null into a slice succeeds and leaves it nil, so insist on '['.
+	if trimmed := strings.TrimLeft(s, " \t\r\n"); !strings.HasPrefix(trimmed, "[") {
+		return List{}, errorf("NewListFromJSON", "input is not a JSON array")
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var raw []interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return List{}, wrapError("NewListFromJSON", err, "invalid JSON array")
+	}
+	if err := dec.Decode(new(json.RawMessage)); err != io.EOF {
+		return List{}, errorf("NewListFromJSON", "trailing data after JSON array")
+	}
+
+	val := make([]string, len(raw))
+	for i, item := range raw {
+		switch v := item.(type) {
+		case string:
+			val[i] = v
+		case json.Number:
+			val[i] = v.String()
+		case bool:
+			val[i] = cast.ToString(v)
+		default:
+			return List{}, errorf("NewListFromJSON", "element %d is %T, want string, number or bool", i, item)
+		}
+	}
+	return newList(val), nil
+}
+
+// NewListFiltered converts va to a List keeping only the elements for which pred returns true.
+func NewListFiltered(va interface{}, pred func(string) bool) List {
 	val := cast.ToStringSlice(va)
-	l := len(val)
-	return List{
-		value:  &val,
-		length: l,
+	kept := make([]string, 0, len(val))
+	for _, v := range val {
+		if pred(v) {
+			kept = append(kept, v)
+		}
+	}
+	return newList(kept)
+}
+
+// NewListFromMap returns the sorted keys of m whose value is true.
+func NewListFromMap(m map[string]bool) List {
+	val := make([]string, 0, len(m))
+	for k, ok := range m {
+		if ok {
+			val = append(val, k)
+		}
+	}
+	sort.Strings(val)
+	return newList(val)
+}
+
+// NewListFromMapKeys returns the sorted keys of m.
+func NewListFromMapKeys(m map[string]interface{}) List {
+	val := make([]string, 0, len(m))
+	for k := range m {
+		val = append(val, k)
+	}
+	sort.Strings(val)
+	return newList(val)
+}
+
+// ListOf returns a list of n copies of value.
+func ListOf(value interface{}, n int) List {
+	if n < 0 {
+		n = 0
+	}
+	str := cast.ToString(value)
+	val := make([]string, n)
+	for i := range val {
+		val[i] = str
+	}
+	return newList(val)
+}
+
+// LinSpace returns n evenly spaced numbers from start to stop inclusive, like
+// numpy.linspace. n == 1 gives [start]; n <= 0 gives an empty list.
+func LinSpace(start, stop float64, n int) List {
+	if n <= 0 {
+		return newList([]string{})
+	}
+
+	val := make([]string, n)
+	step := 0.0
+	if n > 1 {
+		step = (stop - start) / float64(n-1)
+	}
+	for i := range val {
+		f := start + float64(i)*step
+		if i == n-1 && n > 1 {
+			f = stop
+		}
+		val[i] = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return newList(val)
+}
+
+// NewListFromDurations formats each duration with Duration.String.
+func NewListFromDurations(durations []time.Duration) List {
+	val := make([]string, len(durations))
+	for i, dur := range durations {
+		val[i] = dur.String()
+	}
+	return newList(val)
+}
+
+// NewListFromTimes formats each time with layout.
+func NewListFromTimes(times []time.Time, layout string) List {
+	val := make([]string, len(times))
+	for i, t := range times {
+		val[i] = t.Format(layout)
+	}
+	return newList(val)
+}
+
+// NewListFromInts formats each int in base 10. It gives the same list as
+// NewList(ints) without going through cast.
+func NewListFromInts(ints []int) List {
+	val := make([]string, len(ints))
+	for i, n := range ints {
+		val[i] = strconv.Itoa(n)
+	}
+	return newList(val)
+}
+
+// NewListFromInt64s formats each int64 in base 10.
+func NewListFromInt64s(ints []int64) List {
+	val := make([]string, len(ints))
+	for i, n := range ints {
+		val[i] = strconv.FormatInt(n, 10)
+	}
+	return newList(val)
+}
+
+// NewListFromFloat64s formats each float in the shortest form that parses
+// back to it, without an exponent, as NewList does.
+func NewListFromFloat64s(f []float64) List {
+	val := make([]string, len(f))
+	for i, x := range f {
+		val[i] = strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	return newList(val)
+}
+
+// NewListFromStringer calls String on each item.
+func NewListFromStringer(items []fmt.Stringer) List {
+	val := make([]string, len(items))
+	for i, item := range items {
+		val[i] = item.String()
+	}
+	return newList(val)
+}
+
+// Tabulate returns [fn(0), fn(1), ..., fn(n-1)].
+func Tabulate(fn func(int) string, n int) List {
+	if n < 0 {
+		n = 0
+	}
+	val := make([]string, n)
+	for i := range val {
+		val[i] = fn(i)
+	}
+	return newList(val)
+}
+
+// Flatten concatenates lists into a new list. Zero Lists count as empty.
+func Flatten(lists ...List) List {
+	n := 0
+	for _, l := range lists {
+		n += len(l.slice())
+	}
+	val := make([]string, 0, n)
+	for _, l := range lists {
+		val = append(val, l.slice()...)
+	}
+	return newList(val)
+}
+
+// ElementwiseMax returns a list whose element i is the numerically largest
+// element i across lists, like numpy.maximum.reduce. The winning element is
+// kept as written, so ["1.50"] stays "1.50". The lists must have equal
+// lengths and numeric elements; no lists give an empty list.
+func ElementwiseMax(lists ...List) (List, error) {
+	return elementwise("ElementwiseMax", lists, func(a, b float64) bool { return a > b })
+}
+
+// ElementwiseMin is ElementwiseMax for the smallest element.
+func ElementwiseMin(lists ...List) (List, error) {
+	return elementwise("ElementwiseMin", lists, func(a, b float64) bool { return a < b })
+}
+
+// elementwise picks, at every index, the element from lists that beats all
+// the others; earlier lists win ties.
+func elementwise(op string, lists []List, beats func(a, b float64) bool) (List, error) {
+	if len(lists) == 0 {
+		return newList([]string{}), nil
+	}
+	n := len(lists[0].slice())
+	best := make([]float64, n)
+	val := make([]string, n)
+	for k, l := range lists {
+		v := l.slice()
+		if len(v) != n {
+			return List{}, errorf(op, "list %d has %d elements, want %d", k, len(v), n)
+		}
+		for i, item := range v {
+			f, err := parseFloatAt(op, i, item)
+			if err != nil {
+				return List{}, err
+			}
+			if k == 0 || beats(f, best[i]) {
+				best[i], val[i] = f, item
+			}
+		}
+	}
+	return newList(val), nil
+}
+
+// Equal reports whether a and b hold the same elements in order. Each may be
+// a List or anything NewList accepts.
+func Equal(a, b interface{}) bool {
+	return EqualSlices(toStrings(a), toStrings(b))
+}
+
+// EqualSlices reports whether a and b hold the same strings in order. Nil
+// and empty slices are equal.
+func EqualSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// toStrings returns the elements of a List, or v cast to a string slice.
+func toStrings(v interface{}) []string {
+	switch l := v.(type) {
+	case List:
+		return l.slice()
+	case *List:
+		return l.slice()
 	}
+	return cast.ToStringSlice(v)
 }
 
 func NilList(va interface{}) List {
 	var val []string
-	return List{
-		value:  &val,
-		length: 0,
-	}
+	return newList(val)
 }
 
 func NewStrSlice(va interface{}) *[]string {
@@ -39,30 +351,132 @@ func NewStrSlice(va interface{}) *[]string {
 	return &val
 }
 
-func (d List) Min() int {
+// Min returns the smallest element as an int. It returns (0, false) for an
+// empty list and also when any element is not a base-10 int; MinE reports
+// which one.
+func (d List) Min() (int, bool) {
+	min, err := d.extremeInt("Min", func(a, b int) bool { return a < b })
+	return min, err == nil
+}
+
+// Max returns the largest element as an int, failing like Min.
+func (d List) Max() (int, bool) {
+	max, err := d.extremeInt("Max", func(a, b int) bool { return a > b })
+	return max, err == nil
+}
+
+// extremeInt parses every element with strconv.Atoi and returns the one that
+// beats all others. An empty list or an element that does not parse is an
+// error for op.
+func (d List) extremeInt(op string, beats func(a, b int) bool) (int, error) {
+	v := *d.value
+	if len(v) == 0 {
+		return 0, errorf(op, "empty list")
+	}
+	best := 0
+	for i, item := range v {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return 0, wrapError(op, err.(*strconv.NumError).Err, "element %d %q", i, item)
+		}
+		if i == 0 || beats(n, best) {
+			best = n
+		}
+	}
+	return best, nil
+}
+
+// MinE is Min with an error instead of false. The error says whether the list
+// is empty or names the element that is not an int.
+func (d List) MinE() (int, error) {
+	return d.extremeInt("MinE", func(a, b int) bool { return a < b })
+}
+
+// MaxE is Max with an error instead of false, as for MinE.
+func (d List) MaxE() (int, error) {
+	return d.extremeInt("MaxE", func(a, b int) bool { return a > b })
+}
+
+// MustMin is Min for non-empty lists of integers; it panics otherwise.
+func (d List) MustMin() int {
+	min, err := d.extremeInt("MustMin", func(a, b int) bool { return a < b })
+	if err != nil {
+		panic(err)
+	}
+	return min
+}
+
+// MustMax is Max for non-empty lists of integers; it panics otherwise.
+func (d List) MustMax() int {
+	max, err := d.extremeInt("MustMax", func(a, b int) bool { return a > b })
+	if err != nil {
+		panic(err)
+	}
+	return max
+}
 
-	d2 := *d.value
-	d3 := cast.ToIntSlice(d2)
-	sort.Ints(d3)
+// MinString returns the lexicographically smallest element, or ("", false) for an empty list.
+func (d List) MinString() (string, bool) {
+	v := *d.value
+	if len(v) == 0 {
+		return "", false
+	}
+
+	min := v[0]
+	for _, item := range v[1:] {
+		if item < min {
+			min = item
+		}
+	}
+	return min, true
+}
 
-	if d.length > 0 {
-		return d3[0]
+// MaxString returns the lexicographically largest element, or ("", false) for an empty list.
+func (d List) MaxString() (string, bool) {
+	v := *d.value
+	if len(v) == 0 {
+		return "", false
 	}
 
-	return -1
+	max := v[0]
+	for _, item := range v[1:] {
+		if item > max {
+			max = item
+		}
+	}
+	return max, true
 }
 
-func (d List) Max() int {
+// IndexOfMinString returns the index of the first lexicographically smallest element, or -1 for an empty list.
+func (d List) IndexOfMinString() int {
+	v := *d.value
+	if len(v) == 0 {
+		return -1
+	}
 
-	d2 := *d.value
-	d3 := cast.ToIntSlice(d2)
-	sort.Ints(d3)
+	idx := 0
+	for i, item := range v {
+		if item < v[idx] {
+			idx = i
+		}
+	}
+	return idx
+}
 
-	if d.length > 0 {
-		return d3[len(d3)-1]
+// IndexOfMaxString returns the index of the first lexicographically largest element, or -1 for an empty list.
+func (d List) IndexOfMaxString() int {
+	v := *d.value
+	if len(v) == 0 {
+		return -1
 	}
 
-	return -1
+	idx := 0
+	for i, item := range v {
+		if item > v[idx] {
+			idx = i
+		}
+	}
+	return idx
 }
 
 func (d List) Sum() int {
@@ -74,6 +488,155 @@ func (d List) Sum() int {
 	return total
 }
 
+// SumIf sums, as ints, the elements for which predicate returns true.
+func (d List) SumIf(predicate func(string) bool) int {
+	total := 0
+	for _, item := range *d.value {
+		if predicate(item) {
+			total += cast.ToInt(item)
+		}
+	}
+	return total
+}
+
+// SumIfFloat is SumIf with float64 arithmetic.
+func (d List) SumIfFloat(predicate func(string) bool) float64 {
+	total := 0.0
+	for _, item := range *d.value {
+		if predicate(item) {
+			total += cast.ToFloat64(item)
+		}
+	}
+	return total
+}
+
+// L1Norm returns the sum of the absolute values of the elements.
+func (d List) L1Norm() float64 {
+	total := 0.0
+	for _, item := range *d.value {
+		total += math.Abs(cast.ToFloat64(item))
+	}
+	return total
+}
+
+// L2Norm returns the Euclidean length of the list as a vector.
+func (d List) L2Norm() float64 {
+	total := 0.0
+	for _, item := range *d.value {
+		f := cast.ToFloat64(item)
+		total += f * f
+	}
+	return math.Sqrt(total)
+}
+
+// Percentile returns the pth percentile (0 ≤ p ≤ 100) of the elements as
+// numbers, interpolating linearly between closest ranks as numpy does by default.
+// An element that is not a number is an error.
+func (d List) Percentile(p float64) (float64, error) {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, errorf("Percentile", "percentile %v outside [0, 100]", p)
+	}
+	if len(*d.value) == 0 {
+		return 0, errorf("Percentile", "empty list")
+	}
+	fs, err := d.floats("Percentile")
+	if err != nil {
+		return 0, err
+	}
+	sort.Float64s(fs)
+
+	rank := p / 100 * float64(len(fs)-1)
+	lo := int(math.Floor(rank))
+	if lo == len(fs)-1 {
+		return fs[lo], nil
+	}
+	frac := rank - float64(lo)
+	return fs[lo] + frac*(fs[lo+1]-fs[lo]), nil
+}
+
+// Quantile is Percentile(q * 100) for q in [0, 1], so Quantile(0.5) is the median.
+func (d List) Quantile(q float64) (float64, error) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, errorf("Quantile", "quantile %v outside [0, 1]", q)
+	}
+	return d.Percentile(q * 100)
+}
+
+// IQR returns the interquartile range Quantile(0.75) - Quantile(0.25). A list
+// with fewer than two elements, where the spread is meaningless, or with an
+// element that is not a number is an error.
+func (d List) IQR() (float64, error) {
+	if len(*d.value) < 2 {
+		return 0, errorf("IQR", "need at least 2 elements, have %d", len(*d.value))
+	}
+	q1, err := d.Quantile(0.25)
+	if err != nil {
+		return 0, err
+	}
+	q3, err := d.Quantile(0.75)
+	if err != nil {
+		return 0, err
+	}
+	return q3 - q1, nil
+}
+
+// OutlierIndices returns, in order, the indexes of elements outside Tukey's
+// fences [Q1 - k·IQR, Q3 + k·IQR] where k is multiplier: 1.5 for mild
+// outliers, 3 for extreme ones. An element that is not a number is an error.
+func (d List) OutlierIndices(multiplier float64) ([]int, error) {
+	idx := make([]int, 0)
+	fs, err := d.floats("OutlierIndices")
+	if err != nil || len(fs) < 2 {
+		return idx, err
+	}
+
+	q1, _ := d.Quantile(0.25)
+	q3, _ := d.Quantile(0.75)
+	lo, hi := q1-multiplier*(q3-q1), q3+multiplier*(q3-q1)
+	for i, f := range fs {
+		if f < lo || f > hi {
+			idx = append(idx, i)
+		}
+	}
+	return idx, nil
+}
+
+// Clip returns a copy with numeric elements clamped to [minVal, maxVal].
+// Passing math.NaN() for either bound leaves that side unbounded. Elements
+// that are not numbers, or already in range, are kept verbatim.
+func (d List) Clip(minVal, maxVal float64) List {
+	v := *d.value
+	val := make([]string, len(v))
+	for i, item := range v {
+		val[i] = item
+		f, err := strconv.ParseFloat(item, 64)
+		if err != nil {
+			continue
+		}
+		if !math.IsNaN(minVal) && f < minVal {
+			val[i] = strconv.FormatFloat(minVal, 'f', -1, 64)
+		} else if !math.IsNaN(maxVal) && f > maxVal {
+			val[i] = strconv.FormatFloat(maxVal, 'f', -1, 64)
+		}
+	}
+	return newList(val)
+}
+
+// TruncateFloats returns a copy with every numeric element formatted with
+// precision decimal places. Despite the name the value is rounded, as
+// strconv.FormatFloat does. Non-numeric elements are unchanged.
+func (d List) TruncateFloats(precision int) List {
+	v := *d.value
+	val := make([]string, len(v))
+	for i, item := range v {
+		val[i] = item
+		if f, err := strconv.ParseFloat(item, 64); err == nil {
+			val[i] = strconv.FormatFloat(f, 'f', precision, 64)
+		}
+	}
+	return newList(val)
+}
+
 func (d List) Set() List {
 	rdv := *d.value
 	d2Map := make(map[string]bool)
@@ -83,15 +646,49 @@ func (d List) Set() List {
 		}
 	}
 
-	d3Value := make([]string, 0, d.length)
+	d3Value := make([]string, 0, len(rdv))
 	for k, _ := range d2Map {
 		d3Value = append(d3Value, k)
 	}
 
-	return List{
-		value:  &d3Value,
-		length: len(d3Value),
+	return newList(d3Value)
+}
+
+// Unique returns a new list without duplicates, keeping first occurrences in order.
+func (d List) Unique() List {
+	return d.Copy().UniqueInPlace()
+}
+
+// UniqueInPlace drops duplicates from the receiver in a single pass, keeping
+// first occurrences in order. Hooks see one OpRemove per dropped element.
+func (d List) UniqueInPlace() List {
+	d.guard()
+	fats := *d.value
+	seen := make(map[string]bool, len(fats))
+	kept := fats[:0]
+	// Recording every drop costs more than the dedup itself on lists that
+	// are mostly duplicates, so only do it when someone is listening.
+	watched := len(d.state.hooks) > 0
+	var removed []int
+	var dropped []string
+	for i, v := range fats {
+		if seen[v] {
+			if watched {
+				removed = append(removed, i)
+				dropped = append(dropped, v)
+			}
+			continue
+		}
+		seen[v] = true
+		kept = append(kept, v)
+	}
+	*d.value = kept
+	d.state.invalidate()
+
+	for n, i := range removed {
+		d.notify(OpRemove, i-n, dropped[n])
 	}
+	return d
 }
 
 func (d List) Abs() List {
@@ -104,37 +701,144 @@ func (d List) Abs() List {
 		}
 	}
 
-	return List{
-		value:  &d2Value,
-		length: d.length,
-	}
+	return newList(d2Value)
 }
 
+// Copy returns an independent, unfrozen list with the same elements.
 func (d List) Copy() List {
-	return List{
-		value:  &(*d.value),
-		length: d.length,
+	return newList(append([]string(nil), *d.value...))
+}
+
+// Filter returns a new list of the elements for which pred returns true.
+func (d List) Filter(pred func(string) bool) List {
+	val := make([]string, 0)
+	for _, v := range *d.value {
+		if pred(v) {
+			val = append(val, v)
+		}
+	}
+	return newList(val)
+}
+
+// Map returns a new list with fn applied to every element.
+func (d List) Map(fn func(string) string) List {
+	val := make([]string, len(*d.value))
+	for i, v := range *d.value {
+		val[i] = fn(v)
+	}
+	return newList(val)
+}
+
+// Sanitize returns a new list with fn applied to every element, dropping
+// results that are empty when dropEmpty is set.
+func (d List) Sanitize(fn func(string) string, dropEmpty bool) List {
+	val := make([]string, 0, len(*d.value))
+	for _, v := range *d.value {
+		if s := fn(v); s != "" || !dropEmpty {
+			val = append(val, s)
+		}
+	}
+	return newList(val)
+}
+
+// ConcurrentMap is Map with fn run on workers goroutines, each over a
+// contiguous chunk of the list. The result is in the original order.
+func (d List) ConcurrentMap(fn func(string) string, workers int) List {
+	src := *d.value
+	val := make([]string, len(src))
+	inChunks(len(src), workers, func(_, lo, hi int) {
+		for i := lo; i < hi; i++ {
+			val[i] = fn(src[i])
+		}
+	})
+	return newList(val)
+}
+
+// ConcurrentFilter is Filter with pred run on workers goroutines, each over a
+// contiguous chunk of the list. The result equals Filter's.
+func (d List) ConcurrentFilter(pred func(string) bool, workers int) List {
+	src := *d.value
+	workers = clampWorkers(len(src), workers)
+	parts := make([][]string, workers)
+	inChunks(len(src), workers, func(c, lo, hi int) {
+		for _, v := range src[lo:hi] {
+			if pred(v) {
+				parts[c] = append(parts[c], v)
+			}
+		}
+	})
+	val := make([]string, 0)
+	for _, p := range parts {
+		val = append(val, p...)
+	}
+	return newList(val)
+}
+
+// clampWorkers limits workers to between 1 and n, the number of elements.
+func clampWorkers(n, workers int) int {
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
 	}
+	return workers
+}
+
+// inChunks splits [0, n) into at most workers contiguous ranges and calls fn
+// for each one on its own goroutine, returning once all calls have.
+func inChunks(n, workers int, fn func(chunk, lo, hi int)) {
+	workers = clampWorkers(n, workers)
+	var wg sync.WaitGroup
+	for c := 0; c < workers; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			fn(c, c*n/workers, (c+1)*n/workers)
+		}(c)
+	}
+	wg.Wait()
 }
 
 func (d List) Pop(idx int) List {
+	d.guard()
 	fats := *d.value
 	if idx < 0 {
-		idx = d.length + idx
+		idx = len(fats) + idx
 	}
-	if idx > d.length {
+	if idx < 0 || idx >= len(fats) {
 		return d
 	}
 
+	item := fats[idx]
 	*d.value = append(fats[:idx], fats[(idx+1):]...)
+	d.notify(OpPop, idx, item)
 	return d
 }
 
 func (d List) Extend(sub interface{}) List {
-
+	d.guard()
 	subs := cast.ToStringSlice(sub)
+	n := len(*d.value)
+	*d.value = append(*d.value, subs...)
+
+	for i, v := range subs {
+		d.notify(OpExtend, n+i, v)
+	}
+	return d
+}
+
+// AppendList appends the elements of other to the receiver without casting.
+// Hooks see it as OpExtend.
+func (d List) AppendList(other List) List {
+	d.guard()
+	subs := *other.value
+	n := len(*d.value)
 	*d.value = append(*d.value, subs...)
 
+	for i, v := range subs {
+		d.notify(OpExtend, n+i, v)
+	}
 	return d
 }
 
@@ -155,10 +859,7 @@ func (d List) Dup(d2 interface{}) List {
 		d3Value = append(d3Value, k)
 	}
 
-	return List{
-		value:  &d3Value,
-		length: len(d3Value),
-	}
+	return newList(d3Value)
 }
 
 func (d List) In(sub interface{}) bool {
@@ -166,38 +867,70 @@ func (d List) In(sub interface{}) bool {
 	return ok
 }
 
+// Remove deletes every element equal to value. Hooks see one OpRemove per
+// deleted element, indexed as if the deletions happened one after another.
 func (d List) Remove(value interface{}) List {
+	d.guard()
 	fats := *d.value
 	str := cast.ToString(value)
+	kept := fats[:0]
+	var removed []int
 	for i, v := range fats {
 		if v == str {
-			*d.value = append(fats[:i], fats[(i+1):]...)
+			removed = append(removed, i)
+			continue
 		}
+		kept = append(kept, v)
 	}
+	*d.value = kept
 
+	for n, i := range removed {
+		d.notify(OpRemove, i-n, str)
+	}
 	return d
 }
 
 func (d List) Append(value interface{}) List {
+	d.guard()
 	fats := *d.value
 	str := cast.ToString(value)
 	*d.value = append(fats, str)
+	d.notify(OpAppend, len(fats), str)
 	return d
 }
 
 func (d List) Equal(d2 interface{}) bool {
-	dv := cast.ToStringSlice(d2)
-	s1 := *d.value
-	s2 := dv
-	if d.length != len(s2) {
-		return false
+	return EqualSlices(*d.value, cast.ToStringSlice(d2))
+}
+
+// EqualSlice reports whether the list holds exactly the elements of other, in order.
+func (d List) EqualSlice(other []string) bool {
+	return EqualSlices(*d.value, other)
+}
+
+// DeepEqual reports whether both lists hold the same elements and agree on
+// being nil. A zero List and one holding a nil slice are both nil; neither
+// equals an empty list.
+func (d List) DeepEqual(other List) bool {
+	return reflect.DeepEqual(d.slice(), other.slice())
+}
+
+// Clone is Copy that keeps the distinction between a nil and an empty list,
+// and also accepts a zero List.
+func (d List) Clone() List {
+	v := d.slice()
+	if v == nil {
+		return newList(nil)
 	}
-	for i, n := range s1 {
-		if n != s2[i] {
-			return false
-		}
+	return newList(append(make([]string, 0, len(v)), v...))
+}
+
+// slice returns the elements, or nil for a zero List.
+func (d List) slice() []string {
+	if d.value == nil {
+		return nil
 	}
-	return true
+	return *d.value
 }
 
 func (d List) Index(sub interface{}) int {
@@ -205,17 +938,147 @@ func (d List) Index(sub interface{}) int {
 	return index
 }
 
-func (d List) Insert(idx int, value interface{}) List {
-	fats := *d.value
-	str := cast.ToString(value)
-
-	if idx > d.length {
+// Peek returns the element at idx, or ("", false) if idx is out of range.
+func (d List) Peek(idx int) (string, bool) {
+	v := *d.value
+	if idx < 0 || idx >= len(v) {
+		return "", false
+	}
+	return v[idx], true
+}
+
+// HeadElem returns the first element, or ("", false) for an empty list.
+func (d List) HeadElem() (string, bool) {
+	return d.Peek(0)
+}
+
+// TailList returns a copy of every element but the first. The tail of an
+// empty list is empty.
+func (d List) TailList() List {
+	v := *d.value
+	if len(v) == 0 {
+		return newList([]string{})
+	}
+	return newList(append([]string(nil), v[1:]...))
+}
+
+// ReversedIter returns an iterator that yields the elements from last to first
+// without copying the backing slice. It returns ("", false) once exhausted.
+func (d List) ReversedIter() func() (string, bool) {
+	i := len(*d.value)
+	return func() (string, bool) {
+		v := *d.value
+		if i > len(v) {
+			i = len(v)
+		}
+		if i <= 0 {
+			return "", false
+		}
+		i--
+		return v[i], true
+	}
+}
+
+// Reverse returns a new list with the elements in reverse order.
+func (d List) Reverse() List {
+	v := *d.value
+	val := make([]string, len(v))
+	for i, item := range v {
+		val[len(v)-1-i] = item
+	}
+	return newList(val)
+}
+
+// ReverseInPlace reverses the receiver without allocating. It mutates the
+// list, so only use it on lists nobody else reads.
+func (d List) ReverseInPlace() List {
+	d.guard()
+	v := *d.value
+	for i, j := 0, len(v)-1; i < j; i, j = i+1, j-1 {
+		v[i], v[j] = v[j], v[i]
+	}
+	d.notify(OpReverse, -1, "")
+	return d
+}
+
+// Sort returns a new list with the elements in lexicographic order.
+func (d List) Sort() List {
+	l := d.Copy()
+	sort.Strings(*l.value)
+	return l
+}
+
+// SortInPlace sorts the receiver lexicographically without copying it. It
+// mutates the list, so only use it when the original order is not needed.
+func (d List) SortInPlace() List {
+	d.guard()
+	sort.Strings(*d.value)
+	d.notify(OpSort, -1, "")
+	return d
+}
+
+// IsSorted reports whether the elements are in lexicographic order.
+func (d List) IsSorted() bool {
+	return sort.StringsAreSorted(*d.value)
+}
+
+// IsSortedNumeric reports whether the elements are in ascending numeric order,
+// the order SortKey{Mode: Numeric} produces: non-numbers sort after every
+// number, lexicographically among themselves.
+func (d List) IsSortedNumeric() bool {
+	return d.IsSortedFunc(numericLess)
+}
+
+// IsSortedFunc reports whether the elements are ordered according to less.
+func (d List) IsSortedFunc(less func(a, b string) bool) bool {
+	v := *d.value
+	for i := 1; i < len(v); i++ {
+		if less(v[i], v[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// InsertSorted inserts value into a lexicographically sorted list at the
+// position that keeps it sorted, after any equal elements. On an unsorted
+// list the position is unspecified.
+func (d List) InsertSorted(value interface{}) List {
+	return d.InsertSortedFunc(value, func(a, b string) bool { return a < b })
+}
+
+// InsertSortedNumeric is InsertSorted for a list sorted in ascending numeric order.
+func (d List) InsertSortedNumeric(value interface{}) List {
+	return d.InsertSortedFunc(value, numericLess)
+}
+
+// InsertSortedFunc is InsertSorted for a list sorted according to less.
+func (d List) InsertSortedFunc(value interface{}, less func(a, b string) bool) List {
+	str := cast.ToString(value)
+	v := *d.value
+	idx := sort.Search(len(v), func(i int) bool { return less(str, v[i]) })
+	return d.Insert(idx, str)
+}
+
+func numericLess(a, b string) bool {
+	return compareNumeric(a, b) < 0
+}
+
+func (d List) Insert(idx int, value interface{}) List {
+	d.guard()
+	fats := *d.value
+	str := cast.ToString(value)
+
+	if idx < 0 || idx > len(fats) {
 		return d
 	}
 
-	res := append(fats[:idx], str)
-	*d.value = append(res, fats[idx:]...)
+	fats = append(fats, "")
+	copy(fats[idx+1:], fats[idx:])
+	fats[idx] = str
+	*d.value = fats
 
+	d.notify(OpInsert, idx, str)
 	return d
 }
 
@@ -232,9 +1095,602 @@ func (d List) Count(value interface{}) (count int) {
 	return
 }
 
+// Frequency returns how many times each distinct element occurs.
+func (d List) Frequency() map[string]int {
+	freq := make(map[string]int)
+	for _, v := range *d.value {
+		freq[v]++
+	}
+	return freq
+}
+
+// MaxFrequency returns the most common element and its count, or ("", 0) for
+// an empty list. Ties go to the element that occurs first.
+func (d List) MaxFrequency() (string, int) {
+	freq := d.Frequency()
+	best, count := "", 0
+	for _, v := range *d.value {
+		if freq[v] > count {
+			best, count = v, freq[v]
+		}
+	}
+	return best, count
+}
+
+// MinFrequency returns the least common element and its count, or ("", 0)
+// for an empty list. Ties go to the element that occurs first.
+func (d List) MinFrequency() (string, int) {
+	freq := d.Frequency()
+	best, count := "", 0
+	for _, v := range *d.value {
+		if count == 0 || freq[v] < count {
+			best, count = v, freq[v]
+		}
+	}
+	return best, count
+}
+
+// Checkpoint saves the current contents and returns a token for Rollback.
+func (d List) Checkpoint() (token int) {
+	st := d.state
+	token = st.nextToken
+	st.nextToken++
+	st.snapshots = append(st.snapshots, snapshot{
+		token: token,
+		value: append([]string(nil), *d.value...),
+	})
+	return token
+}
+
+// Rollback restores the contents saved by Checkpoint(token). Checkpoints taken
+// after token are discarded; token itself stays valid.
+func (d List) Rollback(token int) error {
+	if err := d.mutable(); err != nil {
+		return err
+	}
+	st := d.state
+	for i, snap := range st.snapshots {
+		if snap.token == token {
+			st.snapshots = st.snapshots[:i+1]
+			return d.replace(snap.value)
+		}
+	}
+	return errorf("Rollback", "unknown checkpoint %d", token)
+}
+
+// replace swaps the contents for a copy of val and reports it as OpRollback.
+func (d List) replace(val []string) error {
+	if err := d.mutable(); err != nil {
+		return err
+	}
+	*d.value = append([]string(nil), val...)
+	d.notify(OpRollback, -1, "")
+	return nil
+}
+
+// CommitAll drops every retained checkpoint.
+func (d List) CommitAll() {
+	d.state.snapshots = nil
+}
+
+// Ops passed to OnChange hooks.
+const (
+	OpAppend   = "append"
+	OpExtend   = "extend"
+	OpInsert   = "insert"
+	OpPop      = "pop"
+	OpRemove   = "remove"
+	OpReverse  = "reverse"  // idx is -1; the whole contents changed
+	OpRollback = "rollback" // idx is -1; the whole contents changed
+	OpSort     = "sort"     // idx is -1; the whole contents changed
+)
+
+// ErrFrozen is returned, or raised by mutators without an error result, once Freeze has been called.
+var ErrFrozen = errors.New("list: list is frozen")
+
+// ErrReentrant is raised when a hook tries to mutate the list that invoked it.
+var ErrReentrant = errors.New("list: mutation from inside an OnChange hook")
+
+// OnChange registers fn to run after every successful mutation. Hooks run in
+// registration order and must not mutate the list; doing so panics with ErrReentrant.
+func (d List) OnChange(fn func(op string, idx int, value string)) {
+	d.state.hooks = append(d.state.hooks, fn)
+}
+
+// ClearHooks removes every hook registered with OnChange.
+func (d List) ClearHooks() {
+	d.state.hooks = nil
+}
+
+// Freeze makes the list read-only: every later mutation fails with ErrFrozen.
+func (d List) Freeze() List {
+	d.state.frozen = true
+	return d
+}
+
+// IsFrozen reports whether Freeze has been called.
+func (d List) IsFrozen() bool {
+	return d.state.frozen
+}
+
+// mutable reports whether the list may be mutated right now. Every mutator
+// must check it, either directly or through guard.
+func (d List) mutable() error {
+	if d.state.frozen {
+		return ErrFrozen
+	}
+	if d.state.firing {
+		return ErrReentrant
+	}
+	return nil
+}
+
+// guard is mutable for the mutators that have no error to return.
+func (d List) guard() {
+	if err := d.mutable(); err != nil {
+		panic(err)
+	}
+}
+
+func (d List) notify(op string, idx int, value string) {
+	st := d.state
+	st.invalidate()
+	if len(st.hooks) == 0 {
+		return
+	}
+	st.firing = true
+	defer func() { st.firing = false }()
+	for _, fn := range st.hooks {
+		fn(op, idx, value)
+	}
+}
+
+// InternStats reports the effect of InternInPlace. Byte counts estimate string
+// data only and assume no element shared storage before the call.
+type InternStats struct {
+	Distinct    int
+	BytesBefore int
+	BytesAfter  int
+}
+
+// InternInPlace makes equal elements share one backing string. The contents do
+// not change, so it is allowed on frozen lists and does not notify hooks.
+// Elements added later are not interned.
+func (d List) InternInPlace() InternStats {
+	v := *d.value
+	seen := make(map[string]string)
+	var stats InternStats
+	for i, item := range v {
+		stats.BytesBefore += len(item)
+		if s, ok := seen[item]; ok {
+			v[i] = s
+			continue
+		}
+		seen[item] = item
+		stats.BytesAfter += len(item)
+	}
+	stats.Distinct = len(seen)
+	return stats
+}
+
+// ForEachCtx calls fn for every element, stopping at the first error from fn
+// or as soon as ctx is done, in which case it returns ctx.Err().
+func (d List) ForEachCtx(ctx context.Context, fn func(idx int, v string) error) error {
+	for i, v := range *d.value {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(i, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Choices draws k elements with replacement, each picked with probability
+// proportional to its weight. A nil r uses the math/rand default source.
+func (d List) Choices(weights []float64, k int, r *rand.Rand) (List, error) {
+	if k < 0 {
+		return List{}, errorf("Choices", "negative count %d", k)
+	}
+	cum, err := d.cumWeights("Choices", weights)
+	if err != nil {
+		return List{}, err
+	}
+
+	val := make([]string, 0, k)
+	for i := 0; i < k; i++ {
+		val = append(val, (*d.value)[pickWeighted(cum, r)])
+	}
+	return newList(val), nil
+}
+
+// ChoiceWeighted draws a single element; see Choices.
+func (d List) ChoiceWeighted(weights []float64, r *rand.Rand) (string, error) {
+	cum, err := d.cumWeights("ChoiceWeighted", weights)
+	if err != nil {
+		return "", err
+	}
+	return (*d.value)[pickWeighted(cum, r)], nil
+}
+
+func (d List) cumWeights(op string, weights []float64) ([]float64, error) {
+	if len(weights) != len(*d.value) {
+		return nil, errorf(op, "%d weights for %d elements", len(weights), len(*d.value))
+	}
+
+	cum := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, errorf(op, "invalid weight %v at index %d", w, i)
+		}
+		total += w
+		cum[i] = total
+	}
+	if total <= 0 {
+		return nil, errorf(op, "all weights are zero")
+	}
+	if math.IsInf(total, 0) {
+		return nil, errorf(op, "weights overflow float64")
+	}
+	return cum, nil
+}
+
+func pickWeighted(cum []float64, r *rand.Rand) int {
+	var f float64
+	if r != nil {
+		f = r.Float64()
+	} else {
+		f = rand.Float64()
+	}
+	x := f * cum[len(cum)-1]
+	// The first cumulative weight above x; zero-weight elements never match.
+	// Rounding in f * total can leave x at the total, so clamp to the last index.
+	i := sort.Search(len(cum), func(i int) bool { return cum[i] > x })
+	if i == len(cum) {
+		i--
+	}
+	return i
+}
+
+// PermutationForSeed returns the index permutation PermutedBySeed applies for
+// seed, so parallel lists can be reordered the same way. The result is stable
+// across runs and releases: element i of the output is the old index at new position i.
+func (d List) PermutationForSeed(seed int64) []int {
+	r := rand.New(rand.NewSource(seed))
+	perm := make([]int, len(*d.value))
+	for i := range perm {
+		perm[i] = i
+	}
+	// Fisher–Yates, written out so the order cannot change with rand.Shuffle.
+	for i := len(perm) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		perm[i], perm[j] = perm[j], perm[i]
+	}
+	return perm
+}
+
+// PermutedBySeed returns a copy shuffled deterministically by seed.
+func (d List) PermutedBySeed(seed int64) List {
+	v := *d.value
+	val := make([]string, len(v))
+	for i, j := range d.PermutationForSeed(seed) {
+		val[i] = v[j]
+	}
+	return newList(val)
+}
+
+// Reduce2 folds the elements from left to right into an accumulator of any
+// type, starting from initial.
+func (d List) Reduce2(fn func(acc interface{}, val string) interface{}, initial interface{}) interface{} {
+	acc := initial
+	for _, v := range *d.value {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// ArgWhere returns the indexes of the elements for which predicate returns
+// true, in order.
+func (d List) ArgWhere(predicate func(string) bool) []int {
+	idx := make([]int, 0)
+	for i, v := range *d.value {
+		if predicate(v) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// CountIf returns the number of elements for which predicate returns true.
+// CountIf is the canonical name, after C++'s std::count_if; CountBy is kept
+// only as an alias.
+func (d List) CountIf(predicate func(string) bool) (count int) {
+	for _, v := range *d.value {
+		if predicate(v) {
+			count++
+		}
+	}
+	return
+}
+
+// CountBy is an alias for CountIf.
+//
+// Deprecated: use CountIf.
+func (d List) CountBy(predicate func(string) bool) int {
+	return d.CountIf(predicate)
+}
+
+// DupPolicy decides what ToPairs does with a key that appears more than once.
+type DupPolicy int
+
+const (
+	KeepFirst DupPolicy = iota
+	KeepLast
+	ErrorOnDuplicate
+)
+
+// ToPairs splits every element on the first sep into a key and a value. An
+// element without sep is an error; see ToPairsAllowBare.
+func (d List) ToPairs(sep string, policy DupPolicy) (map[string]string, error) {
+	return d.toPairs("ToPairs", sep, policy, false)
+}
+
+// ToPairsAllowBare is ToPairs but maps an element without sep to key→"".
+func (d List) ToPairsAllowBare(sep string, policy DupPolicy) (map[string]string, error) {
+	return d.toPairs("ToPairsAllowBare", sep, policy, true)
+}
+
+func (d List) toPairs(op, sep string, policy DupPolicy, allowBare bool) (map[string]string, error) {
+	pairs := make(map[string]string, len(*d.value))
+	firstAt := make(map[string]int, len(*d.value))
+	for i, item := range *d.value {
+		kv := strings.SplitN(item, sep, 2)
+		if len(kv) < 2 {
+			if !allowBare {
+				return nil, errorf(op, "element %d %q has no separator %q", i, item, sep)
+			}
+			kv = append(kv, "")
+		}
+
+		key := kv[0]
+		if j, ok := firstAt[key]; ok {
+			switch policy {
+			case KeepFirst:
+				continue
+			case ErrorOnDuplicate:
+				return nil, errorf(op, "duplicate key %q at indexes %d and %d", key, j, i)
+			}
+		} else {
+			firstAt[key] = i
+		}
+		pairs[key] = kv[1]
+	}
+	return pairs, nil
+}
+
+// AllBetween reports whether every element is a number within [min, max],
+// bounds inclusive. When it is false, idx is the first out-of-range element.
+// A non-numeric element is an error, with idx pointing at it.
+func (d List) AllBetween(min, max float64) (ok bool, idx int, err error) {
+	for i, item := range *d.value {
+		f, err := parseFloatAt("AllBetween", i, item)
+		if err != nil {
+			return false, i, err
+		}
+		if f < min || f > max {
+			return false, i, nil
+		}
+	}
+	return true, -1, nil
+}
+
+// FilterBetween returns the elements within [min, max], bounds inclusive. A
+// non-numeric element is an error.
+func (d List) FilterBetween(min, max float64) (List, error) {
+	val := make([]string, 0)
+	for i, item := range *d.value {
+		f, err := parseFloatAt("FilterBetween", i, item)
+		if err != nil {
+			return List{}, err
+		}
+		if f >= min && f <= max {
+			val = append(val, item)
+		}
+	}
+	return newList(val), nil
+}
+
+// floats parses every element as a float64, failing on the first non-number.
+func (d List) floats(op string) ([]float64, error) {
+	v := *d.value
+	fs := make([]float64, len(v))
+	for i, item := range v {
+		f, err := parseFloatAt(op, i, item)
+		if err != nil {
+			return nil, err
+		}
+		fs[i] = f
+	}
+	return fs, nil
+}
+
+// DotProduct returns the sum of the element-wise products of two numeric
+// lists of equal length.
+func (d List) DotProduct(other List) (float64, error) {
+	a, b, err := d.floatPair("DotProduct", other)
+	if err != nil {
+		return 0, err
+	}
+
+	dot := 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return dot, nil
+}
+
+// CosineSimilarity returns DotProduct / (‖d‖ · ‖other‖). It is an error for
+// either vector to be all zeros.
+func (d List) CosineSimilarity(other List) (float64, error) {
+	a, b, err := d.floatPair("CosineSimilarity", other)
+	if err != nil {
+		return 0, err
+	}
+
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0, errorf("CosineSimilarity", "zero vector")
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb)), nil
+}
+
+func (d List) floatPair(op string, other List) ([]float64, []float64, error) {
+	if len(*d.value) != len(*other.value) {
+		return nil, nil, errorf(op, "length mismatch %d != %d", len(*d.value), len(*other.value))
+	}
+	a, err := d.floats(op)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := other.floats(op)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
+
+func parseFloatAt(op string, i int, s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errorf(op, "element %d %q is not a number", i, s)
+	}
+	return f, nil
+}
+
+// Validate returns nil if pred holds for every element. Otherwise the
+// *ListError it returns wraps a *ValidationError listing every failure.
+func (d List) Validate(pred func(string) bool) error {
+	ve := &ValidationError{}
+	for i, item := range *d.value {
+		if !pred(item) {
+			ve.Indices = append(ve.Indices, i)
+			ve.Values = append(ve.Values, item)
+		}
+	}
+	if len(ve.Indices) == 0 {
+		return nil
+	}
+	return wrapError("Validate", ve, "%d of %d failed", len(ve.Indices), len(*d.value))
+}
+
+// IsEmpty reports whether the list has no elements.
+func (d List) IsEmpty() bool {
+	return len(*d.value) == 0
+}
+
+// ContainsEmptyString reports whether any element is "".
+func (d List) ContainsEmptyString() bool {
+	for _, v := range *d.value {
+		if v == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNumeric reports whether every element parses as a float. It is true for an empty list.
+func (d List) IsNumeric() bool {
+	for _, v := range *d.value {
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// IsUnique reports whether no element appears twice.
+func (d List) IsUnique() bool {
+	seen := make(map[string]bool, len(*d.value))
+	for _, v := range *d.value {
+		if seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+// AllMatchRegex reports whether every element matches pattern. It returns the
+// compile error if pattern is invalid.
+func (d List) AllMatchRegex(pattern string) (bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, wrapError("AllMatchRegex", err, "invalid pattern")
+	}
+	for _, v := range *d.value {
+		if !re.MatchString(v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ParseDurations parses every element with time.ParseDuration. On failure it
+// returns the durations parsed so far along with the error.
+func (d List) ParseDurations() ([]time.Duration, error) {
+	ds := make([]time.Duration, 0, len(*d.value))
+	for i, item := range *d.value {
+		dur, err := time.ParseDuration(item)
+		if err != nil {
+			return ds, wrapError("ParseDurations", err, "element %d", i)
+		}
+		ds = append(ds, dur)
+	}
+	return ds, nil
+}
+
+// ParseTimes parses every element with time.Parse(layout, element). The
+// error names the index and value of the first element that fails.
+func (d List) ParseTimes(layout string) ([]time.Time, error) {
+	ts := make([]time.Time, 0, len(*d.value))
+	for i, item := range *d.value {
+		t, err := time.Parse(layout, item)
+		if err != nil {
+			return ts, wrapError("ParseTimes", err, "element %d %q", i, item)
+		}
+		ts = append(ts, t)
+	}
+	return ts, nil
+}
+
 // Length returns the length
 func (d List) Length() int {
-	return d.length
+	return len(*d.value)
+}
+
+// CachedLength returns the length, recomputing it only after a mutation.
+// Every mutator invalidates the cache through notify.
+// It is safe to call from several goroutines that only read the list.
+func (d List) CachedLength() int {
+	st := d.state
+	if c := atomic.LoadInt64(&st.cachedLen); c > 0 {
+		return int(c - 1)
+	}
+	n := len(*d.value)
+	atomic.StoreInt64(&st.cachedLen, int64(n)+1)
+	return n
+}
+
+// invalidate drops the length cached by CachedLength.
+func (st *listState) invalidate() {
+	atomic.StoreInt64(&st.cachedLen, 0)
 }
 
 func (d List) IntSlice() []int {
@@ -242,6 +1698,115 @@ func (d List) IntSlice() []int {
 	return cast.ToIntSlice(dValue)
 }
 
+// Int64 parses every element as a base-10 int64 with strconv.ParseInt.
+// Elements that are not integers give 0 and out-of-range ones are clamped to
+// the int64 range; ToInt64 reports them instead.
+func (d List) Int64() []int64 {
+	v := *d.value
+	ints := make([]int64, len(v))
+	for i, item := range v {
+		ints[i], _ = strconv.ParseInt(item, 10, 64)
+	}
+	return ints
+}
+
+// ToInt64 is Int64 that fails on the first element that is not a base-10
+// int64. The error unwraps to strconv.ErrSyntax or strconv.ErrRange.
+func (d List) ToInt64() ([]int64, error) {
+	v := *d.value
+	ints := make([]int64, len(v))
+	for i, item := range v {
+		n, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, wrapError("ToInt64", err.(*strconv.NumError).Err, "element %d %q", i, item)
+		}
+		ints[i] = n
+	}
+	return ints, nil
+}
+
+// SafeInt is IntSlice that fails on the first element that is not a base-10
+// int. The error unwraps to strconv.ErrSyntax or strconv.ErrRange.
+func (d List) SafeInt() ([]int, error) {
+	v := *d.value
+	ints := make([]int, len(v))
+	for i, item := range v {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, wrapError("SafeInt", err.(*strconv.NumError).Err, "element %d %q", i, item)
+		}
+		ints[i] = n
+	}
+	return ints, nil
+}
+
+// SafeBool is BoolSlice that accepts only "true", "1" and "yes" or "false",
+// "0" and "no", in any case, and fails on the first other element.
+func (d List) SafeBool() ([]bool, error) {
+	v := *d.value
+	bools := make([]bool, len(v))
+	for i, item := range v {
+		b, ok := parseBool(item)
+		if !ok {
+			return nil, errorf("SafeBool", "element %d %q is not a boolean", i, item)
+		}
+		bools[i] = b
+	}
+	return bools, nil
+}
+
+// parseBool reads the boolean spellings SafeBool accepts; ok is false for
+// anything else.
+func parseBool(s string) (b, ok bool) {
+	switch strings.ToLower(s) {
+	case "true", "1", "yes":
+		return true, true
+	case "false", "0", "no":
+		return false, true
+	}
+	return false, false
+}
+
+// Scan parses every element with fmt.Sscanf(element, format, ...). Each dest
+// must be a pointer to a slice, one per verb in format, and receives one
+// value per element appended to it. As with Sscanf, %s stops at whitespace,
+// not at the text that follows it in format. On error no dest is changed.
+func (d List) Scan(format string, dest ...interface{}) error {
+	slices := make([]reflect.Value, len(dest))
+	out := make([]reflect.Value, len(dest))
+	for k, p := range dest {
+		rv := reflect.ValueOf(p)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+			return errorf("Scan", "dest %d is %T, want a pointer to a slice", k, p)
+		}
+		slices[k] = rv.Elem()
+		out[k] = reflect.MakeSlice(rv.Elem().Type(), 0, len(*d.value))
+	}
+
+	args := make([]interface{}, len(dest))
+	for i, item := range *d.value {
+		for k, s := range slices {
+			args[k] = reflect.New(s.Type().Elem()).Interface()
+		}
+		if _, err := fmt.Sscanf(item, format, args...); err != nil {
+			return wrapError("Scan", err, "element %d %q", i, item)
+		}
+		for k, a := range args {
+			out[k] = reflect.Append(out[k], reflect.ValueOf(a).Elem())
+		}
+	}
+	for k, s := range slices {
+		s.Set(reflect.AppendSlice(s, out[k]))
+	}
+	return nil
+}
+
+// ToFloat64 parses every element as a float64, failing on the first one that
+// is not a number.
+func (d List) ToFloat64() ([]float64, error) {
+	return d.floats("ToFloat64")
+}
+
 func (d List) BoolSlice() []bool {
 	dValue := *d.value
 	return cast.ToBoolSlice(dValue)
@@ -257,6 +1822,615 @@ func (d List) String() string {
 	return "[" + strings.Join(v, " ") + "]"
 }
 
+// ToString formats the list like Python's str(list): ["a", "b", "c"].
+func (d List) ToString() string {
+	v := *d.value
+	quoted := make([]string, len(v))
+	for i, item := range v {
+		quoted[i] = strconv.Quote(item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// Sprint formats l as [a, b, c]: comma-separated like ToString, but with
+// elements unquoted.
+func Sprint(l List) string {
+	return "[" + strings.Join(l.slice(), ", ") + "]"
+}
+
+// Fprintf writes Sprint(l) to w.
+func Fprintf(w io.Writer, l List) error {
+	_, err := io.WriteString(w, Sprint(l))
+	return err
+}
+
+// GoString is used by the %#v verb and returns ToString.
+func (d List) GoString() string {
+	return d.ToString()
+}
+
+// PadToWidth returns a copy with every element padded with spaces to at least
+// width runes, on the right if padRight is set and on the left otherwise.
+// Elements that are already wide enough are unchanged. Width is measured in
+// runes, so wide CJK characters and emoji will not line up visually.
+func (d List) PadToWidth(width int, padRight bool) List {
+	v := *d.value
+	val := make([]string, len(v))
+	for i, item := range v {
+		pad := width - utf8.RuneCountInString(item)
+		switch {
+		case pad <= 0:
+			val[i] = item
+		case padRight:
+			val[i] = item + strings.Repeat(" ", pad)
+		default:
+			val[i] = strings.Repeat(" ", pad) + item
+		}
+	}
+	return newList(val)
+}
+
+// PadToMaxWidth pads every element to the rune width of the longest one.
+func (d List) PadToMaxWidth(padRight bool) List {
+	width := 0
+	for _, item := range *d.value {
+		if n := utf8.RuneCountInString(item); n > width {
+			width = n
+		}
+	}
+	return d.PadToWidth(width, padRight)
+}
+
+// Field returns the nth whitespace-separated field of every element, like
+// awk's $n but counting from 0. Runs of whitespace count as one separator.
+// Negative n counts from the last field. Elements with too few fields give "".
+func (d List) Field(n int) List {
+	return d.fields(n, strings.Fields)
+}
+
+// FieldSep is Field with an explicit separator. Adjacent separators delimit
+// empty fields, as with awk -F.
+func (d List) FieldSep(n int, sep string) List {
+	return d.fields(n, func(s string) []string { return strings.Split(s, sep) })
+}
+
+func (d List) fields(n int, split func(string) []string) List {
+	v := *d.value
+	val := make([]string, len(v))
+	for i, item := range v {
+		f := split(item)
+		idx := n
+		if idx < 0 {
+			idx += len(f)
+		}
+		if idx >= 0 && idx < len(f) {
+			val[i] = f[idx]
+		}
+	}
+	return newList(val)
+}
+
+// ZipWith returns a list whose element i is fn(d[i], other[i]). The result is
+// as long as the shorter of the two lists.
+func (d List) ZipWith(other List, fn func(string, string) string) List {
+	a, b := *d.value, *other.value
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	val := make([]string, n)
+	for i := range val {
+		val[i] = fn(a[i], b[i])
+	}
+	return newList(val)
+}
+
+// PairWith joins element i of the list and of other with sep, so
+// [a b].PairWith([1 2], "=") is [a=1 b=2]. The result is as long as the
+// shorter of the two lists.
+func (d List) PairWith(other List, sep string) List {
+	return d.ZipWith(other, func(k, v string) string { return k + sep + v })
+}
+
+// UnpairWith reverses PairWith, splitting every element on the first sep. An
+// element without sep becomes a key with an empty value.
+func (d List) UnpairWith(sep string) (List, List) {
+	v := *d.value
+	keys, vals := make([]string, len(v)), make([]string, len(v))
+	for i, item := range v {
+		kv := strings.SplitN(item, sep, 2)
+		keys[i] = kv[0]
+		if len(kv) == 2 {
+			vals[i] = kv[1]
+		}
+	}
+	return newList(keys), newList(vals)
+}
+
+// Cycle returns the first n elements of the list repeated forever, so
+// [a b c].Cycle(7) is [a b c a b c a]. It panics if the list is empty and n > 0.
+func (d List) Cycle(n int) List {
+	v := *d.value
+	if n <= 0 {
+		return newList([]string{})
+	}
+	if len(v) == 0 {
+		panic(errorf("Cycle", "empty list"))
+	}
+
+	val := make([]string, n)
+	for i := range val {
+		val[i] = v[i%len(v)]
+	}
+	return newList(val)
+}
+
+// MaxPowerSetLen is the longest list PowerSet accepts; its result has 2^n lists.
+const MaxPowerSetLen = 20
+
+// PowerSet returns all 2^n subsets of the list, from the empty list to the
+// full one. Subset i holds the elements whose bit is set in i, in list order.
+func (d List) PowerSet() ([]List, error) {
+	v := *d.value
+	if len(v) > MaxPowerSetLen {
+		return nil, errorf("PowerSet", "%d elements exceed limit of %d", len(v), MaxPowerSetLen)
+	}
+
+	sets := make([]List, 1<<uint(len(v)))
+	for mask := range sets {
+		val := make([]string, 0, bits.OnesCount(uint(mask)))
+		for i, item := range v {
+			if mask&(1<<uint(i)) != 0 {
+				val = append(val, item)
+			}
+		}
+		sets[mask] = newList(val)
+	}
+	return sets, nil
+}
+
+// Interpose returns a copy with sep inserted between consecutive elements.
+func (d List) Interpose(sep string) List {
+	v := *d.value
+	if len(v) == 0 {
+		return newList([]string{})
+	}
+
+	val := make([]string, 0, 2*len(v)-1)
+	for i, item := range v {
+		if i > 0 {
+			val = append(val, sep)
+		}
+		val = append(val, item)
+	}
+	return newList(val)
+}
+
+// MapKeys treats the list as alternating key/value pairs and returns a copy
+// with fn applied to the keys (even indexes). The list must have even length.
+func (d List) MapKeys(fn func(string) string) (List, error) {
+	return d.mapPairs("MapKeys", 0, fn)
+}
+
+// MapValues is MapKeys for the values (odd indexes).
+func (d List) MapValues(fn func(string) string) (List, error) {
+	return d.mapPairs("MapValues", 1, fn)
+}
+
+func (d List) mapPairs(op string, offset int, fn func(string) string) (List, error) {
+	v := *d.value
+	if len(v)%2 != 0 {
+		return List{}, errorf(op, "%d elements do not form key/value pairs", len(v))
+	}
+
+	val := append([]string(nil), v...)
+	for i := offset; i < len(val); i += 2 {
+		val[i] = fn(val[i])
+	}
+	return newList(val), nil
+}
+
+// ParsedFlatten expands elements that encode lists as "[a,b,c]" into their
+// items, recursively, so ["x", "[a,[b, c]]", "[]"] gives [x a b c]. Items are
+// trimmed of surrounding spaces. Elements that are not a single balanced
+// bracketed list, such as "[a" or "[a][b]", are kept as they are.
+func (d List) ParsedFlatten() List {
+	val := make([]string, 0, len(*d.value))
+	for _, item := range *d.value {
+		val = flattenBracketed(item, val)
+	}
+	return newList(val)
+}
+
+// flattenBracketed appends the items of s to out if s is a bracketed list,
+// expanding nested lists, and s itself otherwise.
+func flattenBracketed(s string, out []string) []string {
+	items, ok := splitBracketed(s)
+	if !ok {
+		return append(out, s)
+	}
+	for _, item := range items {
+		out = flattenBracketed(item, out)
+	}
+	return out
+}
+
+// splitBracketed splits "[a, [b,c], d]" into its top-level items "a",
+// "[b,c]" and "d". ok is false unless s is one balanced bracketed list.
+func splitBracketed(s string) (items []string, ok bool) {
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return nil, false
+	}
+	depth, start := 0, 1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 && i != len(s)-1 {
+				return nil, false
+			}
+		case ',':
+			if depth == 1 {
+				items = append(items, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, false
+	}
+	last := strings.TrimSpace(s[start : len(s)-1])
+	if last != "" || len(items) > 0 {
+		items = append(items, last)
+	}
+	return items, true
+}
+
+// GroupConsecutive splits the list into runs of equal adjacent elements:
+// [a a b c c c] gives [[a a] [b] [c c c]].
+func (d List) GroupConsecutive() []List {
+	v := *d.value
+	groups := make([]List, 0)
+	for start := 0; start < len(v); {
+		end := start + 1
+		for end < len(v) && v[end] == v[start] {
+			end++
+		}
+		groups = append(groups, newList(append([]string(nil), v[start:end]...)))
+		start = end
+	}
+	return groups
+}
+
+// SliceStep returns every step-th element of the range [start, stop).
+// Negative start and stop count from the end and both are clamped to the
+// list. A negative step walks the same range from its last element
+// backwards, so SliceStep(0, n, -1) equals Reverse(). Unlike Python's
+// lst[start:stop:step], the bounds do not swap roles for negative steps.
+func (d List) SliceStep(start, stop, step int) (List, error) {
+	if step == 0 {
+		return List{}, errorf("SliceStep", "step cannot be zero")
+	}
+
+	v := *d.value
+	start, stop = clampIndex(start, len(v)), clampIndex(stop, len(v))
+	val := make([]string, 0)
+	if step > 0 {
+		for i := start; i < stop; i += step {
+			val = append(val, v[i])
+		}
+	} else {
+		for i := stop - 1; i >= start; i += step {
+			val = append(val, v[i])
+		}
+	}
+	return newList(val), nil
+}
+
+// Chunk splits the list into consecutive copies of size elements; the last
+// chunk may be shorter. It panics if size is not positive; see ChunkE.
+func (d List) Chunk(size int) []List {
+	chunks, err := d.ChunkE(size)
+	if err != nil {
+		panic(err)
+	}
+	return chunks
+}
+
+// ChunkE is Chunk with an error for a size that is not positive.
+func (d List) ChunkE(size int) ([]List, error) {
+	if size < 1 {
+		return nil, errorf("ChunkE", "size %d is not positive", size)
+	}
+	chunks := make([]List, 0, (len(*d.value)+size-1)/size)
+	next := d.ChunkIter(size)
+	for chunk, ok := next(); ok; chunk, ok = next() {
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+// Aggregate calls fn on consecutive, non-overlapping groups of groupSize
+// elements and returns the results; the last group may be shorter. The
+// group slice shares the list's storage, so fn must not modify it. Aggregate
+// panics if groupSize is not positive.
+func (d List) Aggregate(fn func([]string) string, groupSize int) List {
+	if groupSize < 1 {
+		panic(errorf("Aggregate", "group size %d is not positive", groupSize))
+	}
+	v := *d.value
+	val := make([]string, 0, (len(v)+groupSize-1)/groupSize)
+	for lo := 0; lo < len(v); lo += groupSize {
+		hi := lo + groupSize
+		if hi > len(v) {
+			hi = len(v)
+		}
+		val = append(val, fn(v[lo:hi:hi]))
+	}
+	return newList(val)
+}
+
+// ChunkIter returns an iterator over consecutive chunks of size elements; the
+// last chunk may be shorter. Each call copies only the chunk it returns, and
+// reports false once the list is exhausted. ChunkIter panics if size is not
+// positive.
+func (d List) ChunkIter(size int) func() (List, bool) {
+	if size < 1 {
+		panic(errorf("ChunkIter", "size %d is not positive", size))
+	}
+	pos := 0
+	return func() (List, bool) {
+		v := *d.value
+		if pos >= len(v) {
+			return List{}, false
+		}
+		end := pos + size
+		if end > len(v) {
+			end = len(v)
+		}
+		chunk := append([]string(nil), v[pos:end]...)
+		pos = end
+		return newList(chunk), true
+	}
+}
+
+// Mask returns the elements whose entry in mask is true, like NumPy boolean
+// indexing. mask must be as long as the list and hold only the spellings
+// SafeBool accepts.
+func (d List) Mask(mask List) (List, error) {
+	return d.mask("Mask", mask)
+}
+
+// Where is Mask under the name pandas and LINQ use: it selects the elements
+// whose mask entry is "true", "1" or "yes" (in any case) and drops those whose
+// entry is "false", "0" or "no". Any other entry, or a mask of a different
+// length, is an error.
+func (d List) Where(mask List) (List, error) {
+	return d.mask("Where", mask)
+}
+
+func (d List) mask(op string, mask List) (List, error) {
+	v, m := *d.value, mask.slice()
+	if len(m) != len(v) {
+		return List{}, errorf(op, "mask of length %d for %d elements", len(m), len(v))
+	}
+	val := make([]string, 0)
+	for i, item := range v {
+		keep, ok := parseBool(m[i])
+		if !ok {
+			return List{}, errorf(op, "mask element %d %q is not a boolean", i, m[i])
+		}
+		if keep {
+			val = append(val, item)
+		}
+	}
+	return newList(val), nil
+}
+
+// Except returns a copy without the elements at indices. Negative indices
+// count from the end and repeated indices are removed once. Any index out of
+// range is an error.
+func (d List) Except(indices ...int) (List, error) {
+	v := *d.value
+	drop := make(map[int]bool, len(indices))
+	for _, i := range indices {
+		idx, err := resolveIndex("Except", i, len(v))
+		if err != nil {
+			return List{}, err
+		}
+		drop[idx] = true
+	}
+
+	val := make([]string, 0, len(v)-len(drop))
+	for i, item := range v {
+		if !drop[i] {
+			val = append(val, item)
+		}
+	}
+	return newList(val), nil
+}
+
+// Keep returns the elements at indices, in the order given, so Keep(2, 0, 1)
+// both selects and reorders. Negative indices count from the end. Any index
+// out of range is an error.
+func (d List) Keep(indices ...int) (List, error) {
+	v := *d.value
+	val := make([]string, len(indices))
+	for n, i := range indices {
+		idx, err := resolveIndex("Keep", i, len(v))
+		if err != nil {
+			return List{}, err
+		}
+		val[n] = v[idx]
+	}
+	return newList(val), nil
+}
+
+// ReIndex returns a copy reordered so element i is d[permutation[i]], the
+// convention PermutationForSeed uses. permutation must contain every index
+// of the list exactly once.
+func (d List) ReIndex(permutation []int) (List, error) {
+	v := *d.value
+	if len(permutation) != len(v) {
+		return List{}, errorf("ReIndex", "permutation of length %d for %d elements", len(permutation), len(v))
+	}
+
+	used := make([]bool, len(v))
+	val := make([]string, len(v))
+	for i, j := range permutation {
+		if j < 0 || j >= len(v) {
+			return List{}, errorf("ReIndex", "index %d out of range for length %d", j, len(v))
+		}
+		if used[j] {
+			return List{}, errorf("ReIndex", "index %d repeated in permutation", j)
+		}
+		used[j] = true
+		val[i] = v[j]
+	}
+	return newList(val), nil
+}
+
+// resolveIndex turns a possibly negative index into a position in a list of length n.
+func resolveIndex(op string, i, n int) (int, error) {
+	idx := i
+	if idx < 0 {
+		idx += n
+	}
+	if idx < 0 || idx >= n {
+		return 0, errorf(op, "index %d out of range for length %d", i, n)
+	}
+	return idx, nil
+}
+
+// clampIndex resolves a possibly negative slice bound against length n.
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+// DefaultPreviewElemLen is the per-element rune cap used by Preview.
+const DefaultPreviewElemLen = 64
+
+// PreviewOpts controls the output of PreviewWith.
+type PreviewOpts struct {
+	// MaxElems is the number of elements rendered; a negative value renders all of them.
+	MaxElems int
+	// MaxElemLen caps each element to that many runes; zero or negative disables the cap.
+	MaxElemLen int
+}
+
+// Preview renders at most n elements, e.g. "[a b c … +1234 more]",
+// eliding elements longer than DefaultPreviewElemLen runes with "…".
+func (d List) Preview(n int) string {
+	return d.PreviewWith(PreviewOpts{MaxElems: n, MaxElemLen: DefaultPreviewElemLen})
+}
+
+// PreviewWith is Preview with both limits configurable.
+func (d List) PreviewWith(opts PreviewOpts) string {
+	v := *d.value
+	shown := len(v)
+	if opts.MaxElems >= 0 && opts.MaxElems < shown {
+		shown = opts.MaxElems
+	}
+
+	parts := make([]string, 0, shown+1)
+	for _, item := range v[:shown] {
+		if opts.MaxElemLen > 0 && utf8.RuneCountInString(item) > opts.MaxElemLen {
+			item = string([]rune(item)[:opts.MaxElemLen]) + "…"
+		}
+		parts = append(parts, item)
+	}
+	if rest := len(v) - shown; rest > 0 {
+		parts = append(parts, "… +"+strconv.Itoa(rest)+" more")
+	}
+
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// MarshalJSON encodes the list as a JSON array of strings.
+func (d List) MarshalJSON() ([]byte, error) {
+	v := d.slice()
+	if v == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(v)
+}
+
+// WriteTo writes the elements to w one per line, implementing io.WriterTo.
+func (d List) WriteTo(w io.Writer) (int64, error) {
+	return d.WriteToSep(w, "\n")
+}
+
+// WriteToSep writes the elements to w joined by sep, buffering the writes.
+// It returns the number of bytes that reached w.
+func (d List) WriteToSep(w io.Writer, sep string) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for i, v := range *d.value {
+		if i > 0 {
+			bw.WriteString(sep)
+		}
+		bw.WriteString(v)
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// ReadFrom appends each line read from r until EOF, implementing
+// io.ReaderFrom. Line endings are "\n" or "\r\n" and are dropped; a final
+// line without one is still appended. It returns the number of bytes read.
+// Nothing is appended if reading fails. Hooks see the lines as OpExtend.
+func (d *List) ReadFrom(r io.Reader) (int64, error) {
+	if d.value == nil {
+		*d = newList(nil)
+	}
+	if err := d.mutable(); err != nil {
+		return 0, err
+	}
+
+	var n int64
+	var lines []string
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		n += int64(len(line))
+		if line != "" {
+			line = strings.TrimSuffix(line, "\n")
+			lines = append(lines, strings.TrimSuffix(line, "\r"))
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+	d.AppendList(newList(lines))
+	return n, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
 func (d List) Value() (driver.Value, error) {
 	return d.String(), nil
 }
@@ -0,0 +1,97 @@
+package list
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var benchmarkSizes = []int{10000, 100000, 1000000}
+
+// benchmarkList returns a list of the decimal numbers 0 to n-1.
+func benchmarkList(n int) List {
+	val := make([]string, n)
+	for i := range val {
+		val[i] = strconv.Itoa(i)
+	}
+	return NewList(val)
+}
+
+// benchmarkSized runs fn as a sub-benchmark for every size in benchmarkSizes,
+// on a list built by mk outside the timed region.
+func benchmarkSized(b *testing.B, mk func(n int) List, fn func(l List)) {
+	for _, n := range benchmarkSizes {
+		l := mk(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				fn(l)
+			}
+		})
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	benchmarkSized(b, benchmarkList, func(l List) {
+		l.Filter(func(s string) bool { return s[len(s)-1] == '7' })
+	})
+}
+
+func BenchmarkMap(b *testing.B) {
+	benchmarkSized(b, benchmarkList, func(l List) {
+		l.Map(strings.ToUpper)
+	})
+}
+
+func BenchmarkSort(b *testing.B) {
+	benchmarkSized(b, benchmarkList, func(l List) {
+		l.Sort()
+	})
+}
+
+func BenchmarkUnique(b *testing.B) {
+	// Every value repeats, so most elements are dropped.
+	repeating := func(n int) List {
+		return benchmarkList(n).Map(func(s string) string {
+			if len(s) > 3 {
+				return s[len(s)-3:]
+			}
+			return s
+		})
+	}
+	benchmarkSized(b, repeating, func(l List) {
+		l.Unique()
+	})
+}
+
+func BenchmarkRemove(b *testing.B) {
+	benchmarkSized(b, benchmarkList, func(l List) {
+		// Remove works in place, so each round removes from a fresh copy.
+		l.Copy().Remove("7")
+	})
+}
+
+func BenchmarkConcurrentMap(b *testing.B) {
+	benchmarkSized(b, benchmarkList, func(l List) {
+		l.ConcurrentMap(strings.ToUpper, 4)
+	})
+}
+
+func BenchmarkNewListFromInts(b *testing.B) {
+	ints := make([]int, 100000)
+	for i := range ints {
+		ints[i] = i
+	}
+	b.Run("NewList", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			NewList(ints)
+		}
+	})
+	b.Run("NewListFromInts", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			NewListFromInts(ints)
+		}
+	})
+}
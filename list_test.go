@@ -1,9 +1,19 @@
 package list
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/spf13/cast"
+	"io"
+	"math"
 	"math/rand"
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -50,6 +60,50 @@ func TestList_Count(t *testing.T) {
 	fmt.Println(NewList(str).Count(1))
 }
 
+func TestList_Frequency(t *testing.T) {
+	freq := NewList([]string{"a", "b", "a", "", "a", ""}).Frequency()
+
+	if len(freq) != 3 || freq["a"] != 3 || freq["b"] != 1 || freq[""] != 2 {
+		t.Errorf("Frequency() = %v", freq)
+	}
+}
+
+func TestList_MaxFrequency(t *testing.T) {
+	cases := []struct {
+		in    []string
+		want  string
+		count int
+	}{
+		{[]string{"x", "y", "y", "z"}, "y", 2},
+		{[]string{"b", "a", "a", "b", "c"}, "b", 2},
+		{[]string{"solo"}, "solo", 1},
+		{nil, "", 0},
+	}
+	for _, c := range cases {
+		if v, n := NewList(c.in).MaxFrequency(); v != c.want || n != c.count {
+			t.Errorf("MaxFrequency(%q) = (%q, %d), want (%q, %d)", c.in, v, n, c.want, c.count)
+		}
+	}
+}
+
+func TestList_MinFrequency(t *testing.T) {
+	cases := []struct {
+		in    []string
+		want  string
+		count int
+	}{
+		{[]string{"x", "y", "y", "x", "z"}, "z", 1},
+		{[]string{"b", "a", "a", "b", "c", "d"}, "c", 1},
+		{[]string{"q", "q"}, "q", 2},
+		{nil, "", 0},
+	}
+	for _, c := range cases {
+		if v, n := NewList(c.in).MinFrequency(); v != c.want || n != c.count {
+			t.Errorf("MinFrequency(%q) = (%q, %d), want (%q, %d)", c.in, v, n, c.want, c.count)
+		}
+	}
+}
+
 func TestList_Dup(t *testing.T) {
 	str := RandomStringSlice()
 
@@ -149,3 +203,2368 @@ func TestList_Sum(t *testing.T) {
 
 	fmt.Println(NewList(str).Sum())
 }
+
+func TestList_EqualSlice(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+
+	if !l.EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("EqualSlice() = false, want true")
+	}
+	if l.EqualSlice([]string{"a", "b"}) {
+		t.Errorf("EqualSlice() with shorter slice = true, want false")
+	}
+	if l.EqualSlice([]string{"a", "c", "b"}) {
+		t.Errorf("EqualSlice() with reordered slice = true, want false")
+	}
+	if !NilList(nil).EqualSlice(nil) {
+		t.Errorf("EqualSlice() on empty list = false, want true")
+	}
+}
+
+func TestList_Preview(t *testing.T) {
+	l := NewList([]string{"a", "b", "c", "d", "e"})
+
+	cases := []struct {
+		n    int
+		want string
+	}{
+		{-1, "[a b c d e]"},
+		{0, "[… +5 more]"},
+		{3, "[a b c … +2 more]"},
+		{5, "[a b c d e]"},
+		{10, "[a b c d e]"},
+	}
+	for _, c := range cases {
+		if got := l.Preview(c.n); got != c.want {
+			t.Errorf("Preview(%d) = %q, want %q", c.n, got, c.want)
+		}
+	}
+
+	if got := NilList(nil).Preview(3); got != "[]" {
+		t.Errorf("Preview() on empty list = %q, want %q", got, "[]")
+	}
+}
+
+func TestList_PreviewWith(t *testing.T) {
+	l := NewList([]string{"short", "abcdefghij", "日本語テキスト", "x"})
+
+	got := l.PreviewWith(PreviewOpts{MaxElems: 3, MaxElemLen: 4})
+	want := "[shor… abcd… 日本語テ… … +1 more]"
+	if got != want {
+		t.Errorf("PreviewWith() = %q, want %q", got, want)
+	}
+
+	got = l.PreviewWith(PreviewOpts{MaxElems: -1, MaxElemLen: 0})
+	want = "[short abcdefghij 日本語テキスト x]"
+	if got != want {
+		t.Errorf("PreviewWith() without limits = %q, want %q", got, want)
+	}
+}
+
+func TestList_HeadElem(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+
+	if v, ok := l.HeadElem(); !ok || v != "a" {
+		t.Errorf("HeadElem() = (%q, %v), want (\"a\", true)", v, ok)
+	}
+	if v, ok := NilList(nil).HeadElem(); ok || v != "" {
+		t.Errorf("HeadElem() of empty list = (%q, %v)", v, ok)
+	}
+}
+
+func TestList_TailList(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+
+	tail := l.TailList()
+	if !tail.EqualSlice([]string{"b", "c"}) {
+		t.Errorf("TailList() = %v, want [b c]", tail)
+	}
+	tail.Append("x")
+	if !l.EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("appending to the tail changed the list to %v", l)
+	}
+	if got := NewList([]string{"a"}).TailList(); got.Length() != 0 {
+		t.Errorf("TailList() of one element = %v", got)
+	}
+	if got := NilList(nil).TailList(); got.Length() != 0 {
+		t.Errorf("TailList() of empty list = %v", got)
+	}
+}
+
+func TestList_Peek(t *testing.T) {
+	l := NewList([]string{"a", "", "c"})
+
+	if v, ok := l.Peek(0); !ok || v != "a" {
+		t.Errorf("Peek(0) = (%q, %v), want (\"a\", true)", v, ok)
+	}
+	if v, ok := l.Peek(1); !ok || v != "" {
+		t.Errorf("Peek(1) = (%q, %v), want (\"\", true)", v, ok)
+	}
+	for _, idx := range []int{-1, 3, 100} {
+		if v, ok := l.Peek(idx); ok || v != "" {
+			t.Errorf("Peek(%d) = (%q, %v), want (\"\", false)", idx, v, ok)
+		}
+	}
+}
+
+func TestList_ReversedIter(t *testing.T) {
+	next := NewList([]string{"a", "b", "c"}).ReversedIter()
+
+	var got []string
+	for v, ok := next(); ok; v, ok = next() {
+		got = append(got, v)
+	}
+	if !NewList(got).EqualSlice([]string{"c", "b", "a"}) {
+		t.Errorf("ReversedIter() yielded %v, want [c b a]", got)
+	}
+	if _, ok := next(); ok {
+		t.Errorf("exhausted iterator returned ok = true")
+	}
+
+	if _, ok := NilList(nil).ReversedIter()(); ok {
+		t.Errorf("ReversedIter() on empty list returned ok = true")
+	}
+}
+
+func TestList_Checkpoint(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+
+	t0 := l.Checkpoint()
+	l.Append("d")
+	l.Remove("a")
+	if !l.EqualSlice([]string{"b", "c", "d"}) {
+		t.Fatalf("after mutations list = %v, want [b c d]", l)
+	}
+
+	t1 := l.Checkpoint()
+	l.Pop(0)
+	t2 := l.Checkpoint()
+	l.Append("e")
+	if !l.EqualSlice([]string{"c", "d", "e"}) {
+		t.Fatalf("after nested mutations list = %v, want [c d e]", l)
+	}
+
+	if err := l.Rollback(t2); err != nil {
+		t.Fatal(err)
+	}
+	if !l.EqualSlice([]string{"c", "d"}) {
+		t.Errorf("Rollback(t2) list = %v, want [c d]", l)
+	}
+
+	if err := l.Rollback(t1); err != nil {
+		t.Fatal(err)
+	}
+	if !l.EqualSlice([]string{"b", "c", "d"}) {
+		t.Errorf("Rollback(t1) list = %v, want [b c d]", l)
+	}
+	if err := l.Rollback(t2); err == nil {
+		t.Errorf("Rollback(t2) after rolling back to t1 succeeded, want error")
+	}
+
+	l.Append("x")
+	if err := l.Rollback(t1); err != nil {
+		t.Fatal(err)
+	}
+	if !l.EqualSlice([]string{"b", "c", "d"}) {
+		t.Errorf("second Rollback(t1) list = %v, want [b c d]", l)
+	}
+
+	if err := l.Rollback(t0); err != nil {
+		t.Fatal(err)
+	}
+	if !l.EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("Rollback(t0) list = %v, want [a b c]", l)
+	}
+}
+
+func TestList_Rollback(t *testing.T) {
+	l := NewList([]string{"a"})
+
+	if err := l.Rollback(0); err == nil {
+		t.Errorf("Rollback() without checkpoints succeeded, want error")
+	}
+
+	tok := l.Checkpoint()
+	l.Copy().Append("b")
+	if err := l.Rollback(tok); err != nil {
+		t.Fatal(err)
+	}
+	if !l.EqualSlice([]string{"a"}) {
+		t.Errorf("Rollback() list = %v, want [a]", l)
+	}
+}
+
+func TestList_CommitAll(t *testing.T) {
+	l := NewList([]string{"a"})
+	tok := l.Checkpoint()
+	l.Append("b")
+	l.CommitAll()
+
+	if err := l.Rollback(tok); err == nil {
+		t.Errorf("Rollback() after CommitAll succeeded, want error")
+	}
+	if !l.EqualSlice([]string{"a", "b"}) {
+		t.Errorf("list = %v, want [a b]", l)
+	}
+	if tok2 := l.Checkpoint(); tok2 == tok {
+		t.Errorf("Checkpoint() after CommitAll reused token %d", tok)
+	}
+}
+
+func TestNewListFiltered(t *testing.T) {
+	l := NewListFiltered([]int{1, -2, 3, -4, 5}, func(s string) bool {
+		return cast.ToInt(s) > 0
+	})
+
+	if !l.EqualSlice([]string{"1", "3", "5"}) {
+		t.Errorf("NewListFiltered() = %v, want [1 3 5]", l)
+	}
+	if l.Length() != 3 {
+		t.Errorf("Length() = %d, want 3", l.Length())
+	}
+
+	none := NewListFiltered([]string{"a", "b"}, func(string) bool { return false })
+	if none.Length() != 0 {
+		t.Errorf("NewListFiltered() rejecting everything has length %d, want 0", none.Length())
+	}
+
+	in := []string{"a", "b", "c", "d"}
+	NewListFiltered(in, func(s string) bool { return s != "a" })
+	if !EqualSlices(in, []string{"a", "b", "c", "d"}) {
+		t.Errorf("NewListFiltered() changed its input to %v", in)
+	}
+}
+
+func TestList_OnChange(t *testing.T) {
+	l := NewList([]string{"a", "x", "b", "x", "x"})
+
+	// mirror replays the events and must end up equal to the list.
+	mirror := append([]string(nil), l.StringSlice()...)
+	var ops []string
+	l.OnChange(func(op string, idx int, value string) {
+		ops = append(ops, op)
+		switch op {
+		case OpAppend, OpExtend, OpInsert:
+			mirror = append(mirror, "")
+			copy(mirror[idx+1:], mirror[idx:])
+			mirror[idx] = value
+		case OpPop, OpRemove:
+			if mirror[idx] != value {
+				t.Errorf("%s at %d reported %q, mirror has %q", op, idx, value, mirror[idx])
+			}
+			mirror = append(mirror[:idx], mirror[idx+1:]...)
+		case OpRollback:
+			mirror = append([]string(nil), l.StringSlice()...)
+		}
+	})
+	var order []int
+	l.OnChange(func(string, int, string) { order = append(order, 2) })
+
+	tok := l.Checkpoint()
+	l.Remove("x")
+	l.Insert(0, "first")
+	l.Insert(2, "mid")
+	l.Append("last")
+	l.Extend([]string{"e1", "e2"})
+	l.Pop(-1)
+	l.Pop(100)
+	it := l.Iterator()
+	for it.Next() {
+		if it.Value() == "mid" {
+			it.Delete()
+		}
+	}
+
+	if !l.EqualSlice(mirror) {
+		t.Errorf("mirror = %v, list = %v", mirror, l)
+	}
+	if !l.EqualSlice([]string{"first", "a", "b", "last", "e1"}) {
+		t.Errorf("list = %v, want [first a b last e1]", l)
+	}
+
+	if err := l.Rollback(tok); err != nil {
+		t.Fatal(err)
+	}
+	if !l.EqualSlice(mirror) || !l.EqualSlice([]string{"a", "x", "b", "x", "x"}) {
+		t.Errorf("after Rollback mirror = %v, list = %v", mirror, l)
+	}
+	want := []string{OpRemove, OpRemove, OpRemove, OpInsert, OpInsert, OpAppend, OpExtend, OpExtend, OpPop, OpRemove, OpRollback}
+	if !NewList(ops).EqualSlice(want) {
+		t.Errorf("ops = %v, want %v", ops, want)
+	}
+	if len(order) != len(ops) {
+		t.Errorf("second hook fired %d times, want %d", len(order), len(ops))
+	}
+}
+
+func TestList_OnChangeReentrant(t *testing.T) {
+	l := NewList([]string{"a"})
+	l.OnChange(func(op string, idx int, value string) {
+		defer func() {
+			if r := recover(); r != ErrReentrant {
+				t.Errorf("mutation inside hook recovered %v, want ErrReentrant", r)
+			}
+		}()
+		l.Append("nested")
+	})
+
+	l.Append("b")
+	if !l.EqualSlice([]string{"a", "b"}) {
+		t.Errorf("list = %v, want [a b]", l)
+	}
+}
+
+func TestList_ClearHooks(t *testing.T) {
+	l := NewList([]string{"a"})
+	calls := 0
+	l.OnChange(func(string, int, string) { calls++ })
+	l.Append("b")
+	l.ClearHooks()
+	l.Append("c")
+
+	if calls != 1 {
+		t.Errorf("hook fired %d times, want 1", calls)
+	}
+}
+
+func TestNewListFromMap(t *testing.T) {
+	l := NewListFromMap(map[string]bool{"c": true, "a": true, "b": false, "d": true})
+
+	if !l.EqualSlice([]string{"a", "c", "d"}) {
+		t.Errorf("NewListFromMap() = %v, want [a c d]", l)
+	}
+	if NewListFromMap(nil).Length() != 0 {
+		t.Errorf("NewListFromMap(nil) is not empty")
+	}
+}
+
+func TestNewListFromMapKeys(t *testing.T) {
+	l := NewListFromMapKeys(map[string]interface{}{"b": 1, "a": nil, "c": false})
+
+	if !l.EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("NewListFromMapKeys() = %v, want [a b c]", l)
+	}
+}
+
+// mutators lists every operation that changes a list in place. New mutators
+// must be added here so TestList_Freeze covers them.
+var mutators = map[string]func(l List) error{
+	"ReadFrom":        func(l List) error { _, err := l.ReadFrom(strings.NewReader("x")); return err },
+	"SortByKeyCached": func(l List) error { l.SortByKeyCached(strings.ToUpper); return nil },
+	"SortByKeys":      func(l List) error { l.SortByKeys(SortKey{}); return nil },
+	"InsertSorted":    func(l List) error { l.InsertSorted("x"); return nil },
+	"Append":          func(l List) error { l.Append("x"); return nil },
+	"AppendList":      func(l List) error { l.AppendList(NewList([]string{"x"})); return nil },
+	"Extend":          func(l List) error { l.Extend([]string{"x"}); return nil },
+	"Insert":          func(l List) error { l.Insert(0, "x"); return nil },
+	"Pop":             func(l List) error { l.Pop(0); return nil },
+	"Remove":          func(l List) error { l.Remove("a"); return nil },
+	"ReverseInPlace":  func(l List) error { l.ReverseInPlace(); return nil },
+	"SortInPlace":     func(l List) error { l.SortInPlace(); return nil },
+	"UniqueInPlace":   func(l List) error { l.UniqueInPlace(); return nil },
+	"Rollback":        func(l List) error { return l.Rollback(l.Checkpoint()) },
+	"Cursor.Delete": func(l List) error {
+		it := l.Iterator()
+		it.Next()
+		return it.Delete()
+	},
+}
+
+func callMutator(fn func(List) error, l List) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, _ = r.(error)
+		}
+	}()
+	return fn(l)
+}
+
+func TestList_Freeze(t *testing.T) {
+	for name, fn := range mutators {
+		l := NewList([]string{"a", "b"}).Freeze()
+		if err := callMutator(fn, l); err != ErrFrozen {
+			t.Errorf("%s on frozen list: got %v, want ErrFrozen", name, err)
+		}
+		if !l.EqualSlice([]string{"a", "b"}) {
+			t.Errorf("%s changed frozen list to %v", name, l)
+		}
+
+		if err := callMutator(fn, NewList([]string{"a", "b"})); err != nil {
+			t.Errorf("%s on unfrozen list: %v", name, err)
+		}
+	}
+}
+
+func TestList_IsFrozen(t *testing.T) {
+	l := NewList([]string{"a", "b"})
+	if l.IsFrozen() {
+		t.Errorf("new list IsFrozen() = true")
+	}
+
+	alias := l
+	l.Freeze()
+	if !alias.IsFrozen() {
+		t.Errorf("copy of the List value is not frozen")
+	}
+
+	id := func(s string) string { return s }
+	all := func(string) bool { return true }
+	for name, derived := range map[string]List{"Copy": l.Copy(), "Filter": l.Filter(all), "Map": l.Map(id)} {
+		if derived.IsFrozen() {
+			t.Errorf("%s of frozen list is frozen", name)
+		}
+		derived.Append("c")
+		if !l.EqualSlice([]string{"a", "b"}) {
+			t.Errorf("appending to %s changed the frozen list to %v", name, l)
+		}
+	}
+}
+
+func TestList_Filter(t *testing.T) {
+	l := NewList([]string{"1", "22", "3", "44"})
+	got := l.Filter(func(s string) bool { return len(s) == 2 })
+
+	if !got.EqualSlice([]string{"22", "44"}) {
+		t.Errorf("Filter() = %v, want [22 44]", got)
+	}
+	if !l.EqualSlice([]string{"1", "22", "3", "44"}) {
+		t.Errorf("Filter() changed the receiver to %v", l)
+	}
+}
+
+func TestList_Map(t *testing.T) {
+	got := NewList([]string{"a", "b"}).Map(func(s string) string { return s + s })
+
+	if !got.EqualSlice([]string{"aa", "bb"}) {
+		t.Errorf("Map() = %v, want [aa bb]", got)
+	}
+}
+
+func TestList_Sanitize(t *testing.T) {
+	l := NewList([]string{" a ", "  ", "B", ""})
+
+	if got := l.Sanitize(strings.TrimSpace, true); !got.EqualSlice([]string{"a", "B"}) {
+		t.Errorf("Sanitize(TrimSpace, true) = %q", *got.value)
+	}
+	if got := l.Sanitize(strings.TrimSpace, false); !got.EqualSlice([]string{"a", "", "B", ""}) {
+		t.Errorf("Sanitize(TrimSpace, false) = %q", *got.value)
+	}
+	if !l.EqualSlice([]string{" a ", "  ", "B", ""}) {
+		t.Errorf("Sanitize() changed the receiver to %q", *l.value)
+	}
+}
+
+func TestList_ConcurrentMap(t *testing.T) {
+	val := make([]string, 1000)
+	for i := range val {
+		val[i] = strconv.Itoa(i)
+	}
+	l := NewList(val)
+	double := func(s string) string { return s + s }
+	want := l.Map(double)
+
+	for _, workers := range []int{-1, 0, 1, 3, 8, 2000} {
+		if got := l.ConcurrentMap(double, workers); !got.EqualSlice(*want.value) {
+			t.Errorf("ConcurrentMap(%d workers) differs from Map", workers)
+		}
+	}
+	if got := NilList(nil).ConcurrentMap(double, 4); got.Length() != 0 {
+		t.Errorf("ConcurrentMap() on empty list = %v", got)
+	}
+}
+
+func TestList_ConcurrentFilter(t *testing.T) {
+	val := make([]string, 1000)
+	for i := range val {
+		val[i] = strconv.Itoa(i)
+	}
+	l := NewList(val)
+	odd := func(s string) bool { return (s[len(s)-1]-'0')%2 == 1 }
+	want := l.Filter(odd)
+
+	for _, workers := range []int{-1, 0, 1, 3, 8, 2000} {
+		if got := l.ConcurrentFilter(odd, workers); !got.EqualSlice(*want.value) {
+			t.Errorf("ConcurrentFilter(%d workers) differs from Filter", workers)
+		}
+	}
+	if got := NilList(nil).ConcurrentFilter(odd, 4); got.Length() != 0 {
+		t.Errorf("ConcurrentFilter() on empty list = %v", got)
+	}
+}
+
+func TestList_ToString(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want string
+	}{
+		{nil, "[]"},
+		{[]string{"a"}, `["a"]`},
+		{[]string{"a", "b c", `say "hi"`, ""}, `["a", "b c", "say \"hi\"", ""]`},
+	}
+	for _, c := range cases {
+		if got := NewList(c.in).ToString(); got != c.want {
+			t.Errorf("ToString(%q) = %s, want %s", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSprint(t *testing.T) {
+	cases := []struct {
+		in   List
+		want string
+	}{
+		{List{}, "[]"},
+		{NewList([]string{"a"}), "[a]"},
+		{NewList([]string{"a", "b c", ""}), "[a, b c, ]"},
+	}
+	for _, c := range cases {
+		if got := Sprint(c.in); got != c.want {
+			t.Errorf("Sprint(%q) = %s, want %s", c.in.slice(), got, c.want)
+		}
+	}
+}
+
+func TestFprintf(t *testing.T) {
+	var sb strings.Builder
+	if err := Fprintf(&sb, NewList([]int{1, 2})); err != nil || sb.String() != "[1, 2]" {
+		t.Errorf("Fprintf() wrote %q, %v", sb.String(), err)
+	}
+
+	_, w := io.Pipe()
+	w.Close()
+	if err := Fprintf(w, NewList([]int{1})); err != io.ErrClosedPipe {
+		t.Errorf("Fprintf() to closed pipe = %v", err)
+	}
+}
+
+func TestList_GoString(t *testing.T) {
+	l := NewList([]string{"a", "b"})
+
+	if got := fmt.Sprintf("%#v", l); got != `["a", "b"]` {
+		t.Errorf("%%#v = %s, want %s", got, `["a", "b"]`)
+	}
+	if got := fmt.Sprint(l); got != "[a b]" {
+		t.Errorf("String() changed: %s", got)
+	}
+}
+
+func TestList_InternInPlace(t *testing.T) {
+	l := NewList([]string{"us", "de", "us", "fr", "de", "us"})
+	stats := l.InternInPlace()
+
+	want := InternStats{Distinct: 3, BytesBefore: 12, BytesAfter: 6}
+	if stats != want {
+		t.Errorf("InternInPlace() = %+v, want %+v", stats, want)
+	}
+	if !l.EqualSlice([]string{"us", "de", "us", "fr", "de", "us"}) {
+		t.Errorf("InternInPlace() changed contents to %v", l)
+	}
+	if got := NilList(nil).InternInPlace(); got != (InternStats{}) {
+		t.Errorf("InternInPlace() on empty list = %+v", got)
+	}
+}
+
+func BenchmarkList_InternInPlace(b *testing.B) {
+	const n = 1000000
+	heap := func() uint64 {
+		var m runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&m)
+		return m.HeapAlloc
+	}
+
+	var saved uint64
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		base := heap()
+		val := make([]string, n)
+		for j := range val {
+			val[j] = "event-type-" + strconv.Itoa(j%500)
+		}
+		l := NewList(val)
+		before := heap() - base
+		b.StartTimer()
+
+		l.InternInPlace()
+
+		b.StopTimer()
+		after := heap() - base
+		runtime.KeepAlive(l)
+		saved += before - after
+		b.StartTimer()
+	}
+	b.ReportMetric(float64(saved)/float64(b.N), "retained-B-saved/op")
+}
+
+func TestList_Reverse(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+	got := l.Reverse()
+
+	if !got.EqualSlice([]string{"c", "b", "a"}) {
+		t.Errorf("Reverse() = %v, want [c b a]", got)
+	}
+	if !l.EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("Reverse() changed the receiver to %v", l)
+	}
+}
+
+func TestList_ReverseInPlace(t *testing.T) {
+	cases := [][]string{nil, {"a"}, {"a", "b"}, {"a", "b", "c", "d", "e"}}
+	for _, c := range cases {
+		l := NewList(append([]string(nil), c...))
+		want := NewList(c).Reverse()
+		l.ReverseInPlace()
+		if !l.Equal(want.StringSlice()) {
+			t.Errorf("ReverseInPlace(%v) = %v, want %v", c, l, want)
+		}
+	}
+}
+
+func TestList_LengthAfterMutation(t *testing.T) {
+	l := NewList([]string{"a", "b"})
+	l.Append("c")
+	l.Pop(0)
+	l.Extend([]string{"d", "e"})
+
+	if l.Length() != 4 {
+		t.Errorf("Length() = %d, want 4", l.Length())
+	}
+	if !l.Equal([]string{"b", "c", "d", "e"}) {
+		t.Errorf("Equal() after mutations = false for %v", l)
+	}
+}
+
+func TestList_Sort(t *testing.T) {
+	l := NewList([]string{"b", "c", "a"})
+	got := l.Sort()
+
+	if !got.EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("Sort() = %v, want [a b c]", got)
+	}
+	if !l.EqualSlice([]string{"b", "c", "a"}) {
+		t.Errorf("Sort() changed the receiver to %v", l)
+	}
+}
+
+func TestList_SortInPlace(t *testing.T) {
+	l := NewList([]string{"b", "10", "a", "9"})
+	var ops []string
+	l.OnChange(func(op string, idx int, value string) { ops = append(ops, op) })
+	l.SortInPlace()
+
+	if !l.EqualSlice([]string{"10", "9", "a", "b"}) {
+		t.Errorf("SortInPlace() = %v, want [10 9 a b]", l)
+	}
+	if len(ops) != 1 || ops[0] != OpSort {
+		t.Errorf("hooks saw %v, want [%s]", ops, OpSort)
+	}
+}
+
+func TestList_Unique(t *testing.T) {
+	l := NewList([]string{"b", "a", "b", "c", "a"})
+	got := l.Unique()
+
+	if !got.EqualSlice([]string{"b", "a", "c"}) {
+		t.Errorf("Unique() = %v, want [b a c]", got)
+	}
+	if l.Length() != 5 {
+		t.Errorf("Unique() changed the receiver to %v", l)
+	}
+}
+
+func TestList_UniqueInPlace(t *testing.T) {
+	l := NewList([]string{"x", "x", "y", "x", "z", "y"})
+	var removed, values []string
+	l.OnChange(func(op string, idx int, value string) {
+		removed = append(removed, strconv.Itoa(idx))
+		values = append(values, value)
+	})
+	l.UniqueInPlace()
+
+	if !l.EqualSlice([]string{"x", "y", "z"}) || l.Length() != 3 {
+		t.Errorf("UniqueInPlace() = %v, want [x y z]", l)
+	}
+	if !NewList(removed).EqualSlice([]string{"1", "2", "3"}) {
+		t.Errorf("hooks saw removals at %v, want [1 2 3]", removed)
+	}
+	if !NewList(values).EqualSlice([]string{"x", "x", "y"}) {
+		t.Errorf("hooks saw removed values %v, want [x x y]", values)
+	}
+}
+
+func TestList_ForEachCtx(t *testing.T) {
+	l := NewList([]string{"a", "b", "c", "d", "e"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var seen []int
+	err := l.ForEachCtx(ctx, func(idx int, v string) error {
+		seen = append(seen, idx)
+		if idx == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("ForEachCtx() = %v, want context.Canceled", err)
+	}
+	if len(seen) != 3 {
+		t.Errorf("ForEachCtx() visited %v after cancel, want [0 1 2]", seen)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = l.ForEachCtx(context.Background(), func(idx int, v string) error {
+		calls++
+		if v == "b" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || calls != 2 {
+		t.Errorf("ForEachCtx() = (%v, %d calls), want (stop, 2 calls)", err, calls)
+	}
+
+	if err := l.ForEachCtx(ctx, func(int, string) error { t.Error("fn called with cancelled context"); return nil }); err != context.Canceled {
+		t.Errorf("ForEachCtx() with cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestList_AppendList(t *testing.T) {
+	l := NewList([]string{"a"})
+	other := NewList([]string{"b", "c"})
+	l.AppendList(other)
+
+	if !l.EqualSlice([]string{"a", "b", "c"}) || l.Length() != 3 {
+		t.Errorf("AppendList() = %v, want [a b c]", l)
+	}
+	if !other.EqualSlice([]string{"b", "c"}) {
+		t.Errorf("AppendList() changed its argument to %v", other)
+	}
+
+	l.AppendList(l)
+	if !l.EqualSlice([]string{"a", "b", "c", "a", "b", "c"}) {
+		t.Errorf("AppendList(self) = %v, want [a b c a b c]", l)
+	}
+}
+
+func TestList_Choices(t *testing.T) {
+	l := NewList([]string{"a", "b", "c", "d"})
+	weights := []float64{1, 2, 0, 7}
+	r := rand.New(rand.NewSource(42))
+
+	const draws = 10000
+	got, err := l.Choices(weights, draws, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Length() != draws {
+		t.Fatalf("Choices() returned %d elements, want %d", got.Length(), draws)
+	}
+	for i, w := range weights {
+		want := w / 10
+		freq := float64(got.Count(l.StringSlice()[i])) / draws
+		if math.Abs(freq-want) > 0.02 {
+			t.Errorf("element %d drawn with frequency %.3f, want %.3f", i, freq, want)
+		}
+	}
+
+	bad := []struct {
+		weights []float64
+		k       int
+	}{
+		{[]float64{1, 2}, 1},
+		{[]float64{1, -1, 1, 1}, 1},
+		{[]float64{0, 0, 0, 0}, 1},
+		{[]float64{1, math.NaN(), 1, 1}, 1},
+		{[]float64{1, math.Inf(1), 1, 1}, 1},
+		{[]float64{math.MaxFloat64, math.MaxFloat64, 1, 1}, 1},
+		{weights, -1},
+	}
+	for _, c := range bad {
+		if _, err := l.Choices(c.weights, c.k, r); err == nil {
+			t.Errorf("Choices(%v, %d) succeeded, want error", c.weights, c.k)
+		}
+	}
+}
+
+func TestList_ChoiceWeighted(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+
+	v, err := l.ChoiceWeighted([]float64{0, 0, 3}, rand.New(rand.NewSource(1)))
+	if err != nil || v != "c" {
+		t.Errorf("ChoiceWeighted() = (%q, %v), want (\"c\", nil)", v, err)
+	}
+	if _, err := NilList(nil).ChoiceWeighted(nil, nil); err == nil {
+		t.Errorf("ChoiceWeighted() on empty list succeeded, want error")
+	}
+}
+
+func TestList_MinMax(t *testing.T) {
+	l := NewList([]string{"3", "-7", "12", "0"})
+
+	if v, ok := l.Min(); !ok || v != -7 {
+		t.Errorf("Min() = (%d, %v), want (-7, true)", v, ok)
+	}
+	if v, ok := l.Max(); !ok || v != 12 {
+		t.Errorf("Max() = (%d, %v), want (12, true)", v, ok)
+	}
+	if v, ok := NilList(nil).Min(); ok || v != 0 {
+		t.Errorf("Min() on empty list = (%d, %v), want (0, false)", v, ok)
+	}
+	if v, ok := NilList(nil).Max(); ok || v != 0 {
+		t.Errorf("Max() on empty list = (%d, %v), want (0, false)", v, ok)
+	}
+
+	for _, in := range [][]string{{"1", "x", "5"}, {"1.5", "3"}} {
+		if v, ok := NewList(in).Min(); ok || v != 0 {
+			t.Errorf("Min(%q) = (%d, %v), want (0, false)", in, v, ok)
+		}
+		if v, ok := NewList(in).Max(); ok || v != 0 {
+			t.Errorf("Max(%q) = (%d, %v), want (0, false)", in, v, ok)
+		}
+	}
+}
+
+func TestList_MustMin(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil || !strings.Contains(err.Error(), `element 1 "x"`) {
+			t.Errorf("MustMin() with a non-integer panicked with %v", err)
+		}
+	}()
+	NewList([]string{"1", "x", "5"}).MustMin()
+}
+
+func TestList_MustMax(t *testing.T) {
+	l := NewList([]int{4, 9, 1})
+	if l.MustMax() != 9 || l.MustMin() != 1 {
+		t.Errorf("MustMax(), MustMin() = %d, %d, want 9, 1", l.MustMax(), l.MustMin())
+	}
+
+	for name, fn := range map[string]func(){"MustMax": func() { NilList(nil).MustMax() }, "MustMin": func() { NilList(nil).MustMin() }} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s() on empty list did not panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestList_PermutedBySeed(t *testing.T) {
+	l := NewList([]string{"a", "b", "c", "d", "e", "f", "g", "h"})
+
+	// Pinned: changing these outputs reshuffles every seeded bucket downstream.
+	if got := l.PermutedBySeed(42); !got.EqualSlice([]string{"e", "f", "h", "d", "a", "g", "c", "b"}) {
+		t.Errorf("PermutedBySeed(42) = %v, want [e f h d a g c b]", got)
+	}
+	if got := l.PermutedBySeed(7); !got.EqualSlice([]string{"b", "e", "c", "a", "f", "d", "h", "g"}) {
+		t.Errorf("PermutedBySeed(7) = %v, want [b e c a f d h g]", got)
+	}
+	if !l.EqualSlice([]string{"a", "b", "c", "d", "e", "f", "g", "h"}) {
+		t.Errorf("PermutedBySeed() changed the receiver to %v", l)
+	}
+	if NilList(nil).PermutedBySeed(1).Length() != 0 {
+		t.Errorf("PermutedBySeed() on empty list is not empty")
+	}
+}
+
+func TestList_PermutationForSeed(t *testing.T) {
+	l := NewList([]string{"a", "b", "c", "d", "e", "f", "g", "h"})
+
+	perm := l.PermutationForSeed(42)
+	if !NewList(perm).Equal([]int{4, 5, 7, 3, 0, 6, 2, 1}) {
+		t.Errorf("PermutationForSeed(42) = %v, want [4 5 7 3 0 6 2 1]", perm)
+	}
+	if again := l.PermutationForSeed(42); !NewList(again).Equal(perm) {
+		t.Errorf("PermutationForSeed(42) is not deterministic: %v then %v", perm, again)
+	}
+}
+
+func TestList_MinE(t *testing.T) {
+	l := NewList([]int{4, 9, 1})
+	if min, err := l.MinE(); min != 1 || err != nil {
+		t.Errorf("MinE() = %d, %v, want 1, nil", min, err)
+	}
+	if max, err := l.MaxE(); max != 9 || err != nil {
+		t.Errorf("MaxE() = %d, %v, want 9, nil", max, err)
+	}
+	if _, err := NilList(nil).MinE(); err == nil {
+		t.Errorf("MinE() on empty list succeeded")
+	}
+	if _, err := NilList(nil).MaxE(); err == nil {
+		t.Errorf("MaxE() on empty list succeeded")
+	}
+
+	bad := NewList([]string{"1", "2", "1.5"})
+	var le *ListError
+	if _, err := bad.MinE(); !errors.As(err, &le) || !strings.Contains(le.Msg, `element 2 "1.5"`) || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("MinE() with a non-integer = %v", err)
+	}
+	if _, err := bad.MaxE(); err == nil || strings.Contains(err.Error(), "empty") {
+		t.Errorf("MaxE() with a non-integer = %v", err)
+	}
+}
+
+func TestList_MinMaxString(t *testing.T) {
+	l := NewList([]string{"bob", "Alice", "carol", "10", "9"})
+
+	if v, ok := l.MinString(); !ok || v != "10" {
+		t.Errorf("MinString() = (%q, %v), want (\"10\", true)", v, ok)
+	}
+	if v, ok := l.MaxString(); !ok || v != "carol" {
+		t.Errorf("MaxString() = (%q, %v), want (\"carol\", true)", v, ok)
+	}
+	if v, ok := NilList(nil).MinString(); ok || v != "" {
+		t.Errorf("MinString() on empty list = (%q, %v), want (\"\", false)", v, ok)
+	}
+	if v, ok := NilList(nil).MaxString(); ok || v != "" {
+		t.Errorf("MaxString() on empty list = (%q, %v), want (\"\", false)", v, ok)
+	}
+}
+
+func TestList_InsertSorted(t *testing.T) {
+	l := NewList([]string{"b", "d", "d", "f"})
+	var at []int
+	l.OnChange(func(op string, idx int, value string) { at = append(at, idx) })
+
+	for _, v := range []string{"a", "c", "d", "z"} {
+		l.InsertSorted(v)
+		if !l.IsSorted() {
+			t.Fatalf("after InsertSorted(%q) list %v is not sorted", v, l)
+		}
+	}
+	if !l.EqualSlice([]string{"a", "b", "c", "d", "d", "d", "f", "z"}) {
+		t.Errorf("InsertSorted() = %v", l)
+	}
+	// The duplicate "d" lands after the existing ones.
+	if !NewList(at).Equal([]int{0, 2, 5, 7}) {
+		t.Errorf("inserted at %v, want [0 2 5 7]", at)
+	}
+
+	unsorted := NewList([]string{"z", "a", "m"})
+	unsorted.InsertSorted("k")
+	if unsorted.Length() != 4 {
+		t.Errorf("InsertSorted() on unsorted list = %v", unsorted)
+	}
+}
+
+func TestList_InsertSortedNumeric(t *testing.T) {
+	l := NewList([]string{"2", "10", "10", "30"})
+
+	for _, v := range []int{1, 10, 20, 100} {
+		l.InsertSortedNumeric(v)
+		if !l.IsSortedNumeric() {
+			t.Fatalf("after InsertSortedNumeric(%d) list %v is not sorted", v, l)
+		}
+	}
+	if !l.EqualSlice([]string{"1", "2", "10", "10", "10", "20", "30", "100"}) {
+		t.Errorf("InsertSortedNumeric() = %v", l)
+	}
+}
+
+func TestList_InsertSortedFunc(t *testing.T) {
+	byLen := func(a, b string) bool { return len(a) < len(b) }
+	l := NewList([]string{"a", "ccc"})
+
+	l.InsertSortedFunc("bb", byLen)
+	l.InsertSortedFunc("dddd", byLen)
+	l.InsertSortedFunc("", byLen)
+	if !l.EqualSlice([]string{"", "a", "bb", "ccc", "dddd"}) || !l.IsSortedFunc(byLen) {
+		t.Errorf("InsertSortedFunc() = %v", l)
+	}
+}
+
+func TestList_IsSorted(t *testing.T) {
+	if !NilList(nil).IsSorted() || !NewList([]string{"a", "a", "b"}).IsSorted() {
+		t.Errorf("IsSorted() = false for a sorted list")
+	}
+	if NewList([]string{"b", "a"}).IsSorted() {
+		t.Errorf("IsSorted() = true for [b a]")
+	}
+	if NewList([]string{"9", "10"}).IsSorted() || !NewList([]string{"9", "10"}).IsSortedNumeric() {
+		t.Errorf("lexicographic and numeric order disagree for [9 10]")
+	}
+	if l := NewList([]string{"x", "5", "1"}).SortedByKeys(SortKey{Mode: Numeric}); !l.IsSortedNumeric() {
+		t.Errorf("IsSortedNumeric() = false for %v sorted by SortKey{Mode: Numeric}", l)
+	}
+	if NewList([]string{"1", "x", "5"}).IsSortedNumeric() {
+		t.Errorf("IsSortedNumeric() = true for [1 x 5]")
+	}
+}
+
+func TestList_IndexOfMinMaxString(t *testing.T) {
+	l := NewList([]string{"m", "b", "z", "b", "z"})
+
+	if got := l.IndexOfMinString(); got != 1 {
+		t.Errorf("IndexOfMinString() = %d, want 1", got)
+	}
+	if got := l.IndexOfMaxString(); got != 2 {
+		t.Errorf("IndexOfMaxString() = %d, want 2", got)
+	}
+	if NilList(nil).IndexOfMinString() != -1 || NilList(nil).IndexOfMaxString() != -1 {
+		t.Errorf("IndexOfMinString/IndexOfMaxString on empty list != -1")
+	}
+}
+
+func TestList_Reduce2(t *testing.T) {
+	l := NewList([]string{"a", "bb", "a", "ccc"})
+
+	total := l.Reduce2(func(acc interface{}, v string) interface{} {
+		return acc.(int) + len(v)
+	}, 0)
+	if total != 7 {
+		t.Errorf("Reduce2() sum of lengths = %v, want 7", total)
+	}
+
+	counts := l.Reduce2(func(acc interface{}, v string) interface{} {
+		m := acc.(map[string]int)
+		m[v]++
+		return m
+	}, map[string]int{}).(map[string]int)
+	if counts["a"] != 2 || counts["bb"] != 1 || len(counts) != 3 {
+		t.Errorf("Reduce2() counts = %v", counts)
+	}
+
+	if got := NilList(nil).Reduce2(nil, "init"); got != "init" {
+		t.Errorf("Reduce2() on empty list = %v, want init", got)
+	}
+}
+
+func TestList_SumIf(t *testing.T) {
+	l := NewList([]string{"3", "-2", "5", "-7", "x"})
+	positive := func(s string) bool { return cast.ToInt(s) > 0 }
+
+	if got := l.SumIf(positive); got != 8 {
+		t.Errorf("SumIf(positive) = %d, want 8", got)
+	}
+	if got := l.SumIf(func(string) bool { return false }); got != 0 {
+		t.Errorf("SumIf(none) = %d, want 0", got)
+	}
+}
+
+func TestList_SumIfFloat(t *testing.T) {
+	l := NewList([]string{"1.5", "-0.25", "2.25"})
+
+	if got := l.SumIfFloat(func(s string) bool { return !strings.HasPrefix(s, "-") }); got != 3.75 {
+		t.Errorf("SumIfFloat() = %v, want 3.75", got)
+	}
+}
+
+func TestList_PadToWidth(t *testing.T) {
+	l := NewList([]string{"a", "abcd", "abcdef"})
+
+	if got := l.PadToWidth(4, true); !got.EqualSlice([]string{"a   ", "abcd", "abcdef"}) {
+		t.Errorf("PadToWidth(4, true) = %q", got.StringSlice())
+	}
+	if got := l.PadToWidth(4, false); !got.EqualSlice([]string{"   a", "abcd", "abcdef"}) {
+		t.Errorf("PadToWidth(4, false) = %q", got.StringSlice())
+	}
+	if !l.EqualSlice([]string{"a", "abcd", "abcdef"}) {
+		t.Errorf("PadToWidth() changed the receiver to %q", l.StringSlice())
+	}
+}
+
+func TestList_PadToMaxWidth(t *testing.T) {
+	// "héllo" is 5 runes but 6 bytes; "日本" is 2 runes but 6 bytes.
+	l := NewList([]string{"héllo", "日本", "ab"})
+
+	if got := l.PadToMaxWidth(true); !got.EqualSlice([]string{"héllo", "日本   ", "ab   "}) {
+		t.Errorf("PadToMaxWidth(true) = %q", got.StringSlice())
+	}
+	if got := l.PadToMaxWidth(false); !got.EqualSlice([]string{"héllo", "   日本", "   ab"}) {
+		t.Errorf("PadToMaxWidth(false) = %q", got.StringSlice())
+	}
+}
+
+func TestList_ArgWhere(t *testing.T) {
+	l := NewList([]string{"x", "ab", "", "cd", "e"})
+	long := func(s string) bool { return len(s) > 1 }
+
+	got := l.ArgWhere(long)
+	if len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("ArgWhere() = %v, want [1 3]", got)
+	}
+	if got := l.ArgWhere(func(s string) bool { return len(s) > 5 }); got == nil || len(got) != 0 {
+		t.Errorf("ArgWhere() with no matches = %#v, want empty", got)
+	}
+}
+
+func TestList_CountIf(t *testing.T) {
+	l := NewList([]string{"apple", "avocado", "banana", ""})
+	startsWithA := func(s string) bool { return strings.HasPrefix(s, "a") }
+
+	if got := l.CountIf(startsWithA); got != 2 {
+		t.Errorf("CountIf() = %d, want 2", got)
+	}
+	if got := l.CountBy(startsWithA); got != l.CountIf(startsWithA) {
+		t.Errorf("CountBy() = %d, differs from CountIf()", got)
+	}
+}
+
+func TestList_Field(t *testing.T) {
+	l := NewList([]string{
+		"root   1  0.0  /sbin/init",
+		"  daemon 42 1.5 sshd  ",
+		"short",
+		"",
+	})
+
+	if got := l.Field(1); !got.EqualSlice([]string{"1", "42", "", ""}) {
+		t.Errorf("Field(1) = %q", got.StringSlice())
+	}
+	if got := l.Field(0); !got.EqualSlice([]string{"root", "daemon", "short", ""}) {
+		t.Errorf("Field(0) = %q", got.StringSlice())
+	}
+	if got := l.Field(-1); !got.EqualSlice([]string{"/sbin/init", "sshd", "short", ""}) {
+		t.Errorf("Field(-1) = %q", got.StringSlice())
+	}
+	if got := l.Field(-5); !got.EqualSlice([]string{"", "", "", ""}) {
+		t.Errorf("Field(-5) = %q", got.StringSlice())
+	}
+}
+
+func TestList_FieldSep(t *testing.T) {
+	l := NewList([]string{"a,b,c", "a,,c", "a", ",x"})
+
+	if got := l.FieldSep(1, ","); !got.EqualSlice([]string{"b", "", "", "x"}) {
+		t.Errorf("FieldSep(1, \",\") = %q", got.StringSlice())
+	}
+	if got := l.FieldSep(-1, ","); !got.EqualSlice([]string{"c", "c", "a", "x"}) {
+		t.Errorf("FieldSep(-1, \",\") = %q", got.StringSlice())
+	}
+	if got := NewList([]string{"k::v"}).FieldSep(1, "::"); !got.EqualSlice([]string{"v"}) {
+		t.Errorf("FieldSep(1, \"::\") = %q", got.StringSlice())
+	}
+}
+
+func TestList_ZipWith(t *testing.T) {
+	a := NewList([]string{"x", "y", "z"})
+	b := NewList([]string{"1", "2"})
+	join := func(l, r string) string { return l + "=" + r }
+
+	if got := a.ZipWith(b, join); !got.EqualSlice([]string{"x=1", "y=2"}) {
+		t.Errorf("ZipWith() = %v, want [x=1 y=2]", got)
+	}
+	if got := b.ZipWith(a, join); !got.EqualSlice([]string{"1=x", "2=y"}) {
+		t.Errorf("ZipWith() = %v, want [1=x 2=y]", got)
+	}
+	if got := a.ZipWith(NilList(nil), join); got.Length() != 0 {
+		t.Errorf("ZipWith(empty) = %v, want []", got)
+	}
+}
+
+func TestList_PairWith(t *testing.T) {
+	keys := NewList([]string{"host", "port", "extra"})
+	vals := NewList([]string{"a=b", "80"})
+
+	pairs := keys.PairWith(vals, "=")
+	if !pairs.EqualSlice([]string{"host=a=b", "port=80"}) {
+		t.Errorf("PairWith() = %v, want [host=a=b port=80]", pairs)
+	}
+
+	k, v := pairs.UnpairWith("=")
+	if !k.EqualSlice([]string{"host", "port"}) || !v.EqualSlice([]string{"a=b", "80"}) {
+		t.Errorf("UnpairWith() = %v, %v", k, v)
+	}
+	k, v = NewList([]string{"bare", "x: y"}).UnpairWith(": ")
+	if !k.EqualSlice([]string{"bare", "x"}) || !v.EqualSlice([]string{"", "y"}) {
+		t.Errorf("UnpairWith() with a bare element = %q, %q", *k.value, *v.value)
+	}
+}
+
+func TestList_ToPairs(t *testing.T) {
+	l := NewList([]string{"HOST=a", "PORT=80", "URL=http://x/?q=1", "HOST=b"})
+
+	first, err := l.ToPairs("=", KeepFirst)
+	if err != nil || first["HOST"] != "a" || first["URL"] != "http://x/?q=1" || len(first) != 3 {
+		t.Errorf("ToPairs(KeepFirst) = %v, %v", first, err)
+	}
+	last, err := l.ToPairs("=", KeepLast)
+	if err != nil || last["HOST"] != "b" || last["PORT"] != "80" {
+		t.Errorf("ToPairs(KeepLast) = %v, %v", last, err)
+	}
+
+	_, err = l.ToPairs("=", ErrorOnDuplicate)
+	if err == nil || !strings.Contains(err.Error(), `"HOST"`) || !strings.Contains(err.Error(), "0 and 3") {
+		t.Errorf("ToPairs(ErrorOnDuplicate) error = %v, want key HOST at 0 and 3", err)
+	}
+	if m, err := l.Pop(-1).ToPairs("=", ErrorOnDuplicate); err != nil || len(m) != 3 {
+		t.Errorf("ToPairs(ErrorOnDuplicate) without duplicates = %v, %v", m, err)
+	}
+}
+
+func TestList_ToPairsAllowBare(t *testing.T) {
+	l := NewList([]string{"a=1", "DEBUG", "b="})
+
+	if _, err := l.ToPairs("=", KeepLast); err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Errorf("ToPairs() with bare element error = %v, want element 1", err)
+	}
+	m, err := l.ToPairsAllowBare("=", KeepLast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := m["DEBUG"]; !ok || v != "" || m["a"] != "1" || m["b"] != "" {
+		t.Errorf("ToPairsAllowBare() = %v", m)
+	}
+}
+
+func TestListOf(t *testing.T) {
+	if got := ListOf(7, 3); !got.EqualSlice([]string{"7", "7", "7"}) {
+		t.Errorf("ListOf(7, 3) = %v", got)
+	}
+	if got := ListOf("x", 0); got.Length() != 0 {
+		t.Errorf("ListOf(x, 0) = %v", got)
+	}
+	if got := ListOf("x", -2); got.Length() != 0 {
+		t.Errorf("ListOf(x, -2) = %v", got)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	a := NewList([]string{"a", "b"})
+	var zero List
+	got := Flatten(a, NilList(nil), zero, NewList([]string{"c"}), a)
+
+	if !got.EqualSlice([]string{"a", "b", "c", "a", "b"}) {
+		t.Errorf("Flatten() = %v, want [a b c a b]", got)
+	}
+	got.Append("x")
+	if !a.EqualSlice([]string{"a", "b"}) {
+		t.Errorf("appending to the result changed an input to %v", a)
+	}
+	if got := Flatten(); got.Length() != 0 {
+		t.Errorf("Flatten() of nothing = %v", got)
+	}
+}
+
+func TestElementwiseMax(t *testing.T) {
+	a := NewList([]string{"1", "5", "-3"})
+	b := NewList([]string{"4", "2.0", "-3.5"})
+	c := NewList([]string{"0", "5.00", "1e-1"})
+
+	got, err := ElementwiseMax(a, b, c)
+	if err != nil || !got.EqualSlice([]string{"4", "5", "1e-1"}) {
+		t.Errorf("ElementwiseMax() = %v, %v, want [4 5 1e-1]", got, err)
+	}
+	got, err = ElementwiseMin(a, b, c)
+	if err != nil || !got.EqualSlice([]string{"0", "2.0", "-3.5"}) {
+		t.Errorf("ElementwiseMin() = %v, %v, want [0 2.0 -3.5]", got, err)
+	}
+	if got, err := ElementwiseMax(); err != nil || got.Length() != 0 {
+		t.Errorf("ElementwiseMax() of nothing = %v, %v", got, err)
+	}
+
+	if _, err := ElementwiseMax(a, NewList([]string{"1"})); err == nil {
+		t.Errorf("ElementwiseMax() of unequal lengths succeeded")
+	}
+	if _, err := ElementwiseMin(a, NewList([]string{"1", "x", "2"})); err == nil || !strings.Contains(err.Error(), `element 1 "x"`) {
+		t.Errorf("ElementwiseMin() with a non-number = %v", err)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	l := NewList([]string{"1", "2"})
+	cases := []struct {
+		a, b interface{}
+		want bool
+	}{
+		{l, []string{"1", "2"}, true},
+		{[]int{1, 2}, l, true},
+		{&l, []interface{}{1, "2"}, true},
+		{l, NewList([]string{"2", "1"}), false},
+		{l, []string{"1"}, false},
+		{List{}, []string{}, true},
+		{nil, NilList(nil), true},
+	}
+	for i, c := range cases {
+		if got := Equal(c.a, c.b); got != c.want {
+			t.Errorf("case %d: Equal(%v, %v) = %v, want %v", i, c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestEqualSlices(t *testing.T) {
+	if !EqualSlices(nil, []string{}) || !EqualSlices([]string{"a"}, []string{"a"}) {
+		t.Errorf("EqualSlices() reported equal slices as different")
+	}
+	if EqualSlices([]string{"a"}, []string{"b"}) || EqualSlices([]string{"a"}, nil) {
+		t.Errorf("EqualSlices() reported different slices as equal")
+	}
+}
+
+func TestList_AllBetween(t *testing.T) {
+	cases := []struct {
+		in      []string
+		ok      bool
+		idx     int
+		wantErr bool
+	}{
+		{[]string{"1", "80", "65535"}, true, -1, false},
+		{[]string{"1", "0", "65536"}, false, 1, false},
+		{[]string{"443", "65536"}, false, 1, false},
+		{[]string{"22", "ssh", "0"}, false, 1, true},
+		{nil, true, -1, false},
+	}
+	for _, c := range cases {
+		ok, idx, err := NewList(c.in).AllBetween(1, 65535)
+		if ok != c.ok || idx != c.idx || (err != nil) != c.wantErr {
+			t.Errorf("AllBetween(1, 65535) on %v = (%v, %d, %v), want (%v, %d, err=%v)", c.in, ok, idx, err, c.ok, c.idx, c.wantErr)
+		}
+	}
+}
+
+func TestList_FilterBetween(t *testing.T) {
+	l := NewList([]string{"-0.1", "0", "55.5", "100", "100.01"})
+
+	got, err := l.FilterBetween(0, 100)
+	if err != nil || !got.EqualSlice([]string{"0", "55.5", "100"}) {
+		t.Errorf("FilterBetween(0, 100) = %v, %v", got, err)
+	}
+	if _, err := NewList([]string{"1", "%"}).FilterBetween(0, 100); err == nil {
+		t.Errorf("FilterBetween() with non-numeric element succeeded")
+	}
+}
+
+func TestList_Cycle(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+
+	if got := l.Cycle(7); !got.EqualSlice([]string{"a", "b", "c", "a", "b", "c", "a"}) {
+		t.Errorf("Cycle(7) = %v", got)
+	}
+	if got := l.Cycle(2); !got.EqualSlice([]string{"a", "b"}) {
+		t.Errorf("Cycle(2) = %v", got)
+	}
+	if got := l.Cycle(0); got.Length() != 0 {
+		t.Errorf("Cycle(0) = %v", got)
+	}
+	if got := NilList(nil).Cycle(0); got.Length() != 0 {
+		t.Errorf("Cycle(0) on empty list = %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Cycle(1) on empty list did not panic")
+		}
+	}()
+	NilList(nil).Cycle(1)
+}
+
+func TestList_Validate(t *testing.T) {
+	hasAt := func(s string) bool { return strings.Contains(s, "@") }
+
+	if err := NewList([]string{"a@x", "b@y"}).Validate(hasAt); err != nil {
+		t.Errorf("Validate() of valid list = %v", err)
+	}
+	if err := NilList(nil).Validate(hasAt); err != nil {
+		t.Errorf("Validate() of empty list = %v", err)
+	}
+
+	err := NewList([]string{"a@x", "bob", "c@z", ""}).Validate(hasAt)
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+	}
+	if len(ve.Indices) != 2 || ve.Indices[0] != 1 || ve.Indices[1] != 3 || ve.Values[0] != "bob" || ve.Values[1] != "" {
+		t.Errorf("ValidationError = %+v", ve)
+	}
+	if want := `list: Validate: 2 of 4 failed: elements 1 "bob", 3 ""`; err.Error() != want {
+		t.Errorf("Error() = %s, want %s", err, want)
+	}
+
+	many := ListOf("x", 8).Validate(hasAt)
+	if !strings.HasSuffix(many.Error(), `4 "x" and 3 more`) {
+		t.Errorf("Error() with 8 failures = %s", many)
+	}
+}
+
+func TestList_IsEmpty(t *testing.T) {
+	if !NilList(nil).IsEmpty() || NewList([]string{""}).IsEmpty() {
+		t.Errorf("IsEmpty() wrong for [] or [\"\"]")
+	}
+}
+
+func TestList_ContainsEmptyString(t *testing.T) {
+	if !NewList([]string{"a", ""}).ContainsEmptyString() {
+		t.Errorf("ContainsEmptyString() = false for [a \"\"]")
+	}
+	if NewList([]string{"a", " "}).ContainsEmptyString() || NilList(nil).ContainsEmptyString() {
+		t.Errorf("ContainsEmptyString() = true without an empty element")
+	}
+}
+
+func TestList_IsNumeric(t *testing.T) {
+	if !NewList([]string{"1", "-2.5", "1e3"}).IsNumeric() || !NilList(nil).IsNumeric() {
+		t.Errorf("IsNumeric() = false for numbers")
+	}
+	if NewList([]string{"1", "two"}).IsNumeric() || NewList([]string{""}).IsNumeric() {
+		t.Errorf("IsNumeric() = true with a non-number")
+	}
+}
+
+func TestList_IsUnique(t *testing.T) {
+	if !NewList([]string{"a", "b", "A"}).IsUnique() || !NilList(nil).IsUnique() {
+		t.Errorf("IsUnique() = false for distinct elements")
+	}
+	if NewList([]string{"a", "b", "a"}).IsUnique() {
+		t.Errorf("IsUnique() = true with a duplicate")
+	}
+}
+
+func TestList_AllMatchRegex(t *testing.T) {
+	l := NewList([]string{"us-east-1", "eu-west-2"})
+
+	if ok, err := l.AllMatchRegex(`^[a-z]+-[a-z]+-\d$`); !ok || err != nil {
+		t.Errorf("AllMatchRegex() = (%v, %v), want (true, nil)", ok, err)
+	}
+	if ok, err := l.Append("local").AllMatchRegex(`^[a-z]+-[a-z]+-\d$`); ok || err != nil {
+		t.Errorf("AllMatchRegex() = (%v, %v), want (false, nil)", ok, err)
+	}
+	var le *ListError
+	if _, err := l.AllMatchRegex(`(`); !errors.As(err, &le) || le.Err == nil {
+		t.Errorf("AllMatchRegex() with invalid pattern = %v, want a wrapped *ListError", err)
+	}
+}
+
+func TestLinSpace(t *testing.T) {
+	cases := []struct {
+		start, stop float64
+		n           int
+		want        []string
+	}{
+		{0, 1, 5, []string{"0", "0.25", "0.5", "0.75", "1"}},
+		{10, 0, 3, []string{"10", "5", "0"}},
+		{0, 1, 3, []string{"0", "0.5", "1"}},
+		{2, 9, 1, []string{"2"}},
+		{0, 1, 0, []string{}},
+	}
+	for _, c := range cases {
+		if got := LinSpace(c.start, c.stop, c.n); !got.EqualSlice(c.want) {
+			t.Errorf("LinSpace(%v, %v, %d) = %v, want %v", c.start, c.stop, c.n, got, c.want)
+		}
+	}
+
+	if got := LinSpace(0, 0.3, 4); got.StringSlice()[3] != "0.3" {
+		t.Errorf("LinSpace(0, 0.3, 4) ends at %s, want exactly 0.3", got.StringSlice()[3])
+	}
+}
+
+type color int
+
+func (c color) String() string { return [...]string{"red", "green"}[c] }
+
+func TestNewListReflect(t *testing.T) {
+	var nilErr *os.PathError
+	cases := []struct {
+		name string
+		in   interface{}
+		want []string
+	}{
+		{"errors", []error{errors.New("boom"), io.EOF}, []string{"boom", "EOF"}},
+		{"net.IP", []net.IP{net.IPv4(10, 0, 0, 1), net.ParseIP("::1")}, []string{"10.0.0.1", "::1"}},
+		{"time.Time", []time.Time{time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)}, []string{"2021-03-04 05:06:07 +0000 UTC"}},
+		{"Stringer", []color{1, 0}, []string{"green", "red"}},
+		{"array", [2]int{3, 4}, []string{"3", "4"}},
+		{"nil element", []error{nilErr}, []string{"<nil>"}},
+		{"single", color(0), []string{"red"}},
+		{"single string", "a,b", []string{"a,b"}},
+		{"nil", nil, []string{}},
+		{"empty", []error{}, []string{}},
+	}
+	for _, c := range cases {
+		got, err := NewListReflect(c.in)
+		if err != nil || !got.EqualSlice(c.want) {
+			t.Errorf("%s: NewListReflect() = %q, %v, want %q", c.name, got.StringSlice(), err, c.want)
+		}
+	}
+
+	if _, err := NewListReflect(make(chan int)); err == nil {
+		t.Errorf("NewListReflect(chan) succeeded, want error")
+	}
+}
+
+func TestList_DotProduct(t *testing.T) {
+	a := NewList([]string{"1", "2", "3"})
+
+	if got, err := a.DotProduct(NewList([]string{"4", "-5", "0.5"})); err != nil || got != -4.5 {
+		t.Errorf("DotProduct() = %v, %v, want -4.5", got, err)
+	}
+	if _, err := a.DotProduct(NewList([]string{"1", "2"})); err == nil {
+		t.Errorf("DotProduct() with length mismatch succeeded")
+	}
+	if _, err := a.DotProduct(NewList([]string{"1", "x", "3"})); err == nil {
+		t.Errorf("DotProduct() with non-numeric element succeeded")
+	}
+	if got, err := NilList(nil).DotProduct(NilList(nil)); err != nil || got != 0 {
+		t.Errorf("DotProduct() of empty lists = %v, %v, want 0", got, err)
+	}
+}
+
+func TestList_CosineSimilarity(t *testing.T) {
+	a := NewList([]string{"1", "0"})
+
+	cases := []struct {
+		other []string
+		want  float64
+	}{
+		{[]string{"2", "0"}, 1},
+		{[]string{"0", "3"}, 0},
+		{[]string{"-1", "0"}, -1},
+		{[]string{"1", "1"}, 1 / math.Sqrt2},
+	}
+	for _, c := range cases {
+		got, err := a.CosineSimilarity(NewList(c.other))
+		if err != nil || math.Abs(got-c.want) > 1e-12 {
+			t.Errorf("CosineSimilarity(%v) = %v, %v, want %v", c.other, got, err, c.want)
+		}
+	}
+	if _, err := a.CosineSimilarity(NewList([]string{"0", "0"})); err == nil {
+		t.Errorf("CosineSimilarity() with zero vector succeeded")
+	}
+}
+
+func TestList_ReadFrom(t *testing.T) {
+	l := NewList([]string{"x"})
+	var ops []string
+	l.OnChange(func(op string, idx int, value string) {
+		ops = append(ops, fmt.Sprintf("%s %d %s", op, idx, value))
+	})
+
+	in := "a\r\n\nb\nc"
+	n, err := l.ReadFrom(strings.NewReader(in))
+	if err != nil || n != int64(len(in)) {
+		t.Fatalf("ReadFrom() = %d, %v, want %d, nil", n, err, len(in))
+	}
+	if !l.EqualSlice([]string{"x", "a", "", "b", "c"}) {
+		t.Errorf("after ReadFrom() list = %v, want [x a  b c]", l)
+	}
+	if len(ops) != 4 || ops[0] != "extend 1 a" {
+		t.Errorf("hooks saw %v", ops)
+	}
+
+	var zero List
+	var _ io.ReaderFrom = &zero
+	if _, err := zero.ReadFrom(strings.NewReader("p\nq\n")); err != nil || !zero.EqualSlice([]string{"p", "q"}) {
+		t.Errorf("ReadFrom() into zero List = %v, %v", zero, err)
+	}
+
+	var sb strings.Builder
+	l.WriteTo(&sb)
+	back := NilList(nil)
+	back.ReadFrom(strings.NewReader(sb.String()))
+	if !back.EqualSlice(*l.value) {
+		t.Errorf("WriteTo then ReadFrom = %v, want %v", back, l)
+	}
+
+	frozen := NewList([]string{"a"}).Freeze()
+	r := strings.NewReader("b")
+	if _, err := frozen.ReadFrom(r); err != ErrFrozen || r.Len() != 1 {
+		t.Errorf("ReadFrom() on frozen list = %v, consumed %d bytes", err, 1-r.Len())
+	}
+}
+
+func TestNewListFromJSON(t *testing.T) {
+	got, err := NewListFromJSON(` ["a", 1, 2.50, true, "", 12345678901234567890] `)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.EqualSlice([]string{"a", "1", "2.50", "true", "", "12345678901234567890"}) {
+		t.Errorf("NewListFromJSON() = %q", got.StringSlice())
+	}
+
+	for _, bad := range []string{`"a"`, `[`, `[{"k": 1}]`, `[["a"]]`, `[null]`, `["a"] ["b"]`, `{}`, `null`, ` null `, `["a"]]`, `["a"],`} {
+		if _, err := NewListFromJSON(bad); err == nil {
+			t.Errorf("NewListFromJSON(%s) succeeded, want error", bad)
+		}
+	}
+	if got, err := NewListFromJSON(`[]`); err != nil || got.Length() != 0 {
+		t.Errorf("NewListFromJSON([]) = %v, %v", got, err)
+	}
+}
+
+func TestList_DeepEqual(t *testing.T) {
+	var zero List
+	empty := NewList([]string{})
+	cases := []struct {
+		a, b List
+		want bool
+	}{
+		{zero, zero, true},
+		{zero, NilList(nil), true},
+		{zero, empty, false},
+		{NilList(nil), empty, false},
+		{empty, NewList([]string{}), true},
+		{NewList([]string{"a", "b"}), NewList([]string{"a", "b"}), true},
+		{NewList([]string{"a", "b"}), NewList([]string{"b", "a"}), false},
+		{NewList([]string{"a"}), zero, false},
+	}
+	for i, c := range cases {
+		if got := c.a.DeepEqual(c.b); got != c.want {
+			t.Errorf("case %d: %v.DeepEqual(%v) = %v, want %v", i, c.a.slice(), c.b.slice(), got, c.want)
+		}
+		if got := c.b.DeepEqual(c.a); got != c.want {
+			t.Errorf("case %d: DeepEqual is not symmetric", i)
+		}
+	}
+}
+
+func TestList_Clone(t *testing.T) {
+	var zero List
+	for _, l := range []List{zero, NilList(nil), NewList([]string{}), NewList([]string{"a", "b"})} {
+		c := l.Clone()
+		if !c.DeepEqual(l) {
+			t.Errorf("Clone() of %#v = %#v", l.slice(), c.slice())
+		}
+		c.Append("x")
+		if l.DeepEqual(c) {
+			t.Errorf("Clone() of %#v shares storage", l.slice())
+		}
+	}
+}
+
+func TestList_MarshalJSON(t *testing.T) {
+	l := NewList([]string{`say "hi"`, "a,b", "[x]", "", "日本", "\n"})
+
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := NewListFromJSON(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !back.Equal(l.StringSlice()) {
+		t.Errorf("round trip through %s gave %q", data, back.StringSlice())
+	}
+
+	if data, _ := json.Marshal(NilList(nil)); string(data) != "[]" {
+		t.Errorf("MarshalJSON() of empty list = %s, want []", data)
+	}
+
+	var unset struct {
+		Tags List `json:"tags"`
+	}
+	if data, err := json.Marshal(unset); err != nil || string(data) != `{"tags":[]}` {
+		t.Errorf("json.Marshal() with a zero List field = %s, %v", data, err)
+	}
+}
+
+func TestList_WriteTo(t *testing.T) {
+	l := NewList([]string{"a", "", "日本"})
+
+	var sb strings.Builder
+	var _ io.WriterTo = l
+	n, err := l.WriteTo(&sb)
+	if err != nil || sb.String() != "a\n\n日本" || n != int64(sb.Len()) {
+		t.Errorf("WriteTo() wrote %q, n = %d, err = %v", sb.String(), n, err)
+	}
+
+	sb.Reset()
+	n, err = l.WriteToSep(&sb, ", ")
+	if err != nil || sb.String() != "a, , 日本" || n != int64(sb.Len()) {
+		t.Errorf("WriteToSep() wrote %q, n = %d, err = %v", sb.String(), n, err)
+	}
+
+	sb.Reset()
+	if n, err := NilList(nil).WriteTo(&sb); n != 0 || err != nil || sb.Len() != 0 {
+		t.Errorf("WriteTo() of empty list = %d, %v", n, err)
+	}
+
+	_, w := io.Pipe()
+	w.Close()
+
+	n, err = l.WriteToSep(w, ",")
+	if err != io.ErrClosedPipe || n != 0 {
+		t.Errorf("WriteToSep() to closed pipe = %d, %v, want 0, io.ErrClosedPipe", n, err)
+	}
+}
+
+func TestList_L1Norm(t *testing.T) {
+	if got := NewList([]string{"3", "-4", "0.5"}).L1Norm(); got != 7.5 {
+		t.Errorf("L1Norm() = %v, want 7.5", got)
+	}
+	if got := NilList(nil).L1Norm(); got != 0 {
+		t.Errorf("L1Norm() of empty list = %v, want 0", got)
+	}
+}
+
+func TestList_L2Norm(t *testing.T) {
+	if got := NewList([]string{"3", "-4"}).L2Norm(); got != 5 {
+		t.Errorf("L2Norm() = %v, want 5", got)
+	}
+	if got := NilList(nil).L2Norm(); got != 0 {
+		t.Errorf("L2Norm() of empty list = %v, want 0", got)
+	}
+}
+
+func TestList_Percentile(t *testing.T) {
+	l := NewList([]string{"15", "20", "35", "40", "50"})
+
+	cases := map[float64]float64{0: 15, 25: 20, 40: 29, 50: 35, 100: 50}
+	for p, want := range cases {
+		if got, err := l.Percentile(p); err != nil || math.Abs(got-want) > 1e-9 {
+			t.Errorf("Percentile(%v) = %v, %v, want %v", p, got, err, want)
+		}
+	}
+	for _, p := range []float64{-1, 100.5, math.NaN()} {
+		if _, err := l.Percentile(p); err == nil {
+			t.Errorf("Percentile(%v) succeeded, want error", p)
+		}
+	}
+	if _, err := NilList(nil).Percentile(50); err == nil {
+		t.Errorf("Percentile() of empty list succeeded, want error")
+	}
+	var le *ListError
+	if _, err := NewList([]string{"1", "abc", "3", "100"}).Percentile(50); !errors.As(err, &le) || !strings.Contains(le.Msg, `element 1 "abc"`) {
+		t.Errorf("Percentile() with a non-number = %v", err)
+	}
+	if _, err := NewList([]string{"1", "x"}).Quantile(0.5); err == nil {
+		t.Errorf("Quantile() with a non-number succeeded")
+	}
+}
+
+func TestList_Quantile(t *testing.T) {
+	l := NewList([]string{"4", "1", "3", "2"})
+
+	if got, err := l.Quantile(0.5); err != nil || got != 2.5 {
+		t.Errorf("Quantile(0.5) = %v, %v, want 2.5", got, err)
+	}
+	if got, err := l.Quantile(1); err != nil || got != 4 {
+		t.Errorf("Quantile(1) = %v, %v, want 4", got, err)
+	}
+	if _, err := l.Quantile(50); err == nil {
+		t.Errorf("Quantile(50) succeeded, want error")
+	}
+}
+
+func TestList_IQR(t *testing.T) {
+	l := NewList([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9"})
+
+	if got, err := l.IQR(); got != 4 || err != nil {
+		t.Errorf("IQR() = (%v, %v), want (4, nil)", got, err)
+	}
+	if got, err := NewList([]string{"2", "2"}).IQR(); got != 0 || err != nil {
+		t.Errorf("IQR() of two equal elements = (%v, %v), want (0, nil)", got, err)
+	}
+	for _, l := range []List{NewList([]string{"7"}), NilList(nil), NewList([]string{"1", "x", "3"})} {
+		if got, err := l.IQR(); err == nil {
+			t.Errorf("IQR() of %v = %v, want error", l, got)
+		}
+	}
+}
+
+func TestList_OutlierIndices(t *testing.T) {
+	// Q1 = 3, Q3 = 7, IQR = 4: mild fences [-3, 13], extreme fences [-9, 19].
+	l := NewList([]string{"5", "18", "2", "-10", "3", "4", "6", "7", "13"})
+
+	if got, err := l.OutlierIndices(1.5); err != nil || !NewList(got).Equal([]int{1, 3}) {
+		t.Errorf("OutlierIndices(1.5) = (%v, %v), want [1 3]", got, err)
+	}
+	if got, err := l.OutlierIndices(3); err != nil || !NewList(got).Equal([]int{3}) {
+		t.Errorf("OutlierIndices(3) = (%v, %v), want [3]", got, err)
+	}
+	if got, err := NilList(nil).OutlierIndices(1.5); err != nil || len(got) != 0 {
+		t.Errorf("OutlierIndices() of empty list = (%v, %v)", got, err)
+	}
+	var le *ListError
+	if _, err := NewList([]string{"1", "2", "n/a", "40"}).OutlierIndices(1.5); !errors.As(err, &le) || !strings.Contains(le.Msg, `element 2 "n/a"`) {
+		t.Errorf("OutlierIndices() with a non-number = %v", err)
+	}
+}
+
+func TestList_Clip(t *testing.T) {
+	l := NewList([]string{"-5", "0.50", "7", "12", "n/a"})
+
+	cases := []struct {
+		min, max float64
+		want     []string
+	}{
+		{0, 10, []string{"0", "0.50", "7", "10", "n/a"}},
+		{math.NaN(), 10, []string{"-5", "0.50", "7", "10", "n/a"}},
+		{1.5, math.NaN(), []string{"1.5", "1.5", "7", "12", "n/a"}},
+		{math.NaN(), math.NaN(), []string{"-5", "0.50", "7", "12", "n/a"}},
+	}
+	for _, c := range cases {
+		if got := l.Clip(c.min, c.max); !got.EqualSlice(c.want) {
+			t.Errorf("Clip(%v, %v) = %v, want %v", c.min, c.max, got, c.want)
+		}
+	}
+}
+
+func TestList_PowerSet(t *testing.T) {
+	sets, err := NewList([]string{"a", "b", "c"}).PowerSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{{}, {"a"}, {"b"}, {"a", "b"}, {"c"}, {"a", "c"}, {"b", "c"}, {"a", "b", "c"}}
+	if len(sets) != len(want) {
+		t.Fatalf("PowerSet() returned %d subsets, want %d", len(sets), len(want))
+	}
+	for i, w := range want {
+		if !sets[i].EqualSlice(w) {
+			t.Errorf("subset %d = %v, want %v", i, sets[i], w)
+		}
+	}
+
+	if sets, err := NilList(nil).PowerSet(); err != nil || len(sets) != 1 || sets[0].Length() != 0 {
+		t.Errorf("PowerSet() of empty list = %v, %v, want [[]]", sets, err)
+	}
+	if _, err := ListOf("x", MaxPowerSetLen+1).PowerSet(); err == nil {
+		t.Errorf("PowerSet() over the limit succeeded")
+	}
+}
+
+func TestList_Interpose(t *testing.T) {
+	cases := []struct {
+		in, want []string
+	}{
+		{[]string{"a", "b", "c"}, []string{"a", ",", "b", ",", "c"}},
+		{[]string{"a"}, []string{"a"}},
+		{nil, []string{}},
+	}
+	for _, c := range cases {
+		if got := NewList(c.in).Interpose(","); !got.EqualSlice(c.want) {
+			t.Errorf("Interpose(%v) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestTabulate(t *testing.T) {
+	got := Tabulate(func(i int) string { return strconv.Itoa(i * i) }, 4)
+
+	if !got.EqualSlice([]string{"0", "1", "4", "9"}) {
+		t.Errorf("Tabulate() = %v, want [0 1 4 9]", got)
+	}
+	if got := Tabulate(strconv.Itoa, 0); got.Length() != 0 {
+		t.Errorf("Tabulate(fn, 0) = %v", got)
+	}
+	if got := Tabulate(strconv.Itoa, -1); got.Length() != 0 {
+		t.Errorf("Tabulate(fn, -1) = %v", got)
+	}
+}
+
+func TestList_MapKeys(t *testing.T) {
+	l := NewList([]string{"host", "a", "port", "80"})
+
+	got, err := l.MapKeys(strings.ToUpper)
+	if err != nil || !got.EqualSlice([]string{"HOST", "a", "PORT", "80"}) {
+		t.Errorf("MapKeys() = %v, %v", got, err)
+	}
+	if !l.EqualSlice([]string{"host", "a", "port", "80"}) {
+		t.Errorf("MapKeys() changed the receiver to %v", l)
+	}
+	if _, err := NewList([]string{"k", "v", "dangling"}).MapKeys(strings.ToUpper); err == nil {
+		t.Errorf("MapKeys() on odd-length list succeeded")
+	}
+}
+
+func TestList_MapValues(t *testing.T) {
+	l := NewList([]string{"host", "a", "port", "80"})
+
+	got, err := l.MapValues(func(s string) string { return "<" + s + ">" })
+	if err != nil || !got.EqualSlice([]string{"host", "<a>", "port", "<80>"}) {
+		t.Errorf("MapValues() = %v, %v", got, err)
+	}
+	if got, err := NilList(nil).MapValues(strings.ToUpper); err != nil || got.Length() != 0 {
+		t.Errorf("MapValues() on empty list = %v, %v", got, err)
+	}
+}
+
+func TestList_ParsedFlatten(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want []string
+	}{
+		{[]string{"x", "[a,b,c]", "y"}, []string{"x", "a", "b", "c", "y"}},
+		{[]string{"[a, [b, [c]], d]"}, []string{"a", "b", "c", "d"}},
+		{[]string{"[]", "[ ]", "[[]]"}, []string{}},
+		{[]string{"[a,,b]", "[a,]"}, []string{"a", "", "b", "a", ""}},
+		{[]string{"[a", "a]", "[a][b]", "[a]]", "[[a]", " [a]"}, []string{"[a", "a]", "[a][b]", "[a]]", "[[a]", " [a]"}},
+		{[]string{"[x, [y][z]]"}, []string{"x", "[y][z]"}},
+	}
+	for _, c := range cases {
+		if got := NewList(c.in).ParsedFlatten(); !got.EqualSlice(c.want) {
+			t.Errorf("ParsedFlatten(%q) = %q, want %q", c.in, *got.value, c.want)
+		}
+	}
+}
+
+func TestList_GroupConsecutive(t *testing.T) {
+	groups := NewList([]string{"a", "a", "b", "c", "c", "c", "a"}).GroupConsecutive()
+
+	want := [][]string{{"a", "a"}, {"b"}, {"c", "c", "c"}, {"a"}}
+	if len(groups) != len(want) {
+		t.Fatalf("GroupConsecutive() = %v, want %v", groups, want)
+	}
+	for i, w := range want {
+		if !groups[i].EqualSlice(w) {
+			t.Errorf("group %d = %v, want %v", i, groups[i], w)
+		}
+	}
+	if got := NilList(nil).GroupConsecutive(); len(got) != 0 {
+		t.Errorf("GroupConsecutive() of empty list = %v", got)
+	}
+}
+
+func TestList_SliceStep(t *testing.T) {
+	l := NewList([]string{"0", "1", "2", "3", "4", "5"})
+
+	cases := []struct {
+		start, stop, step int
+		want              []string
+	}{
+		{0, 6, 1, []string{"0", "1", "2", "3", "4", "5"}},
+		{0, 6, 2, []string{"0", "2", "4"}},
+		{1, 5, 3, []string{"1", "4"}},
+		{0, 6, -1, []string{"5", "4", "3", "2", "1", "0"}},
+		{1, 5, -2, []string{"4", "2"}},
+		{-3, 100, 1, []string{"3", "4", "5"}},
+		{-100, -4, 1, []string{"0", "1"}},
+		{4, 2, 1, []string{}},
+		{4, 2, -1, []string{}},
+	}
+	for _, c := range cases {
+		got, err := l.SliceStep(c.start, c.stop, c.step)
+		if err != nil || !got.EqualSlice(c.want) {
+			t.Errorf("SliceStep(%d, %d, %d) = %v, %v, want %v", c.start, c.stop, c.step, got, err, c.want)
+		}
+	}
+
+	rev, _ := l.SliceStep(0, l.Length(), -1)
+	if !rev.Equal(l.Reverse().StringSlice()) {
+		t.Errorf("SliceStep(0, n, -1) = %v, differs from Reverse()", rev)
+	}
+	if _, err := l.SliceStep(0, 6, 0); err == nil {
+		t.Errorf("SliceStep() with step 0 succeeded")
+	}
+}
+
+func TestList_Aggregate(t *testing.T) {
+	l := NewList([]int{1, 2, 3, 4, 5, 6, 7})
+	mean := func(group []string) string {
+		sum := 0.0
+		for _, s := range group {
+			sum += cast.ToFloat64(s)
+		}
+		return strconv.FormatFloat(sum/float64(len(group)), 'f', -1, 64)
+	}
+
+	if got := l.Aggregate(mean, 3); !got.EqualSlice([]string{"2", "5", "7"}) {
+		t.Errorf("Aggregate(mean, 3) = %v, want [2 5 7]", got)
+	}
+	join := func(group []string) string { return strings.Join(group, "") }
+	if got := l.Aggregate(join, 10); !got.EqualSlice([]string{"1234567"}) {
+		t.Errorf("Aggregate(join, 10) = %v", got)
+	}
+	if got := NilList(nil).Aggregate(join, 2); got.Length() != 0 {
+		t.Errorf("Aggregate() of empty list = %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Aggregate(fn, 0) did not panic")
+		}
+	}()
+	l.Aggregate(join, 0)
+}
+
+func TestList_ChunkIter(t *testing.T) {
+	l := NewList([]string{"a", "b", "c", "d", "e"})
+	next := l.ChunkIter(2)
+
+	var got []string
+	for chunk, ok := next(); ok; chunk, ok = next() {
+		got = append(got, strings.Join(*chunk.value, ","))
+	}
+	if !NewList(got).EqualSlice([]string{"a,b", "c,d", "e"}) {
+		t.Errorf("ChunkIter(2) chunks = %v, want [a,b c,d e]", got)
+	}
+	if _, ok := next(); ok {
+		t.Errorf("exhausted iterator returned another chunk")
+	}
+
+	if _, ok := NilList(nil).ChunkIter(3)(); ok {
+		t.Errorf("ChunkIter() on empty list returned a chunk")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ChunkIter(0) did not panic")
+		}
+	}()
+	l.ChunkIter(0)
+}
+
+func TestList_Chunk(t *testing.T) {
+	l := NewList([]string{"a", "b", "c", "d", "e"})
+
+	chunks := l.Chunk(2)
+	if len(chunks) != 3 || !chunks[0].EqualSlice([]string{"a", "b"}) || !chunks[2].EqualSlice([]string{"e"}) {
+		t.Errorf("Chunk(2) = %v", chunks)
+	}
+	chunks[0].Append("x")
+	if !l.EqualSlice([]string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("appending to a chunk changed the list to %v", l)
+	}
+
+	if chunks, err := NilList(nil).ChunkE(3); err != nil || len(chunks) != 0 {
+		t.Errorf("ChunkE(3) on empty list = %v, %v", chunks, err)
+	}
+	if _, err := l.ChunkE(0); err == nil {
+		t.Errorf("ChunkE(0) succeeded")
+	}
+}
+
+func TestList_Mask(t *testing.T) {
+	l := NewList([]string{"a", "b", "c", "d"})
+	isVowel := l.Map(func(s string) string { return strconv.FormatBool(strings.ContainsAny(s, "aeiou")) })
+
+	if got, err := l.Mask(isVowel); err != nil || !got.EqualSlice([]string{"a"}) {
+		t.Errorf("Mask(%v) = %v, %v", isVowel, got, err)
+	}
+	if got, err := l.Mask(NewList([]string{"1", "no", "YES", "0"})); err != nil || !got.EqualSlice([]string{"a", "c"}) {
+		t.Errorf("Mask() with mixed spellings = %v, %v", got, err)
+	}
+	if _, err := l.Mask(NewList([]string{"true"})); err == nil {
+		t.Errorf("Mask() of the wrong length succeeded")
+	}
+	if _, err := l.Mask(NewList([]string{"1", "0", "maybe", "1"})); err == nil || !strings.Contains(err.Error(), `2 "maybe"`) {
+		t.Errorf("Mask() with a non-boolean = %v", err)
+	}
+}
+
+func TestList_Where(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+	mask := NewList([]string{"true", "false", "1"})
+
+	got, err := l.Where(mask)
+	if err != nil || !got.EqualSlice([]string{"a", "c"}) {
+		t.Errorf("Where(%v) = %v, %v", mask, got, err)
+	}
+	if _, err := l.Where(NilList(nil)); err == nil || !strings.Contains(err.Error(), "Where") {
+		t.Errorf("Where() with an empty mask = %v", err)
+	}
+}
+
+func TestList_Except(t *testing.T) {
+	l := NewList([]string{"a", "b", "c", "d"})
+
+	cases := []struct {
+		indices []int
+		want    []string
+	}{
+		{[]int{1}, []string{"a", "c", "d"}},
+		{[]int{0, -1}, []string{"b", "c"}},
+		{[]int{2, 2, -2}, []string{"a", "b", "d"}},
+		{nil, []string{"a", "b", "c", "d"}},
+		{[]int{0, 1, 2, 3}, []string{}},
+	}
+	for _, c := range cases {
+		got, err := l.Except(c.indices...)
+		if err != nil || !got.EqualSlice(c.want) {
+			t.Errorf("Except(%v) = %v, %v, want %v", c.indices, got, err, c.want)
+		}
+	}
+	for _, bad := range []int{4, -5} {
+		if _, err := l.Except(0, bad); err == nil {
+			t.Errorf("Except(0, %d) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestList_Keep(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+
+	cases := []struct {
+		indices []int
+		want    []string
+	}{
+		{[]int{2, 0, 1}, []string{"c", "a", "b"}},
+		{[]int{-1, 0, 0}, []string{"c", "a", "a"}},
+		{nil, []string{}},
+	}
+	for _, c := range cases {
+		got, err := l.Keep(c.indices...)
+		if err != nil || !got.EqualSlice(c.want) {
+			t.Errorf("Keep(%v) = %v, %v, want %v", c.indices, got, err, c.want)
+		}
+	}
+	if _, err := l.Keep(0, 3); err == nil {
+		t.Errorf("Keep(0, 3) succeeded, want error")
+	}
+}
+
+func TestList_ReIndex(t *testing.T) {
+	l := NewList([]string{"a", "b", "c"})
+
+	got, err := l.ReIndex([]int{2, 0, 1})
+	if err != nil || !got.EqualSlice([]string{"c", "a", "b"}) {
+		t.Errorf("ReIndex([2 0 1]) = %v, %v, want [c a b]", got, err)
+	}
+
+	perm := l.PermutationForSeed(3)
+	shuffled, err := l.ReIndex(perm)
+	if err != nil || !shuffled.Equal(l.PermutedBySeed(3).StringSlice()) {
+		t.Errorf("ReIndex(PermutationForSeed(3)) = %v, %v, want %v", shuffled, err, l.PermutedBySeed(3))
+	}
+
+	for _, bad := range [][]int{{0, 1}, {0, 1, 3}, {0, -1, 2}, {0, 0, 1}} {
+		if _, err := l.ReIndex(bad); err == nil {
+			t.Errorf("ReIndex(%v) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestList_TruncateFloats(t *testing.T) {
+	l := NewList([]string{"3.14159", "2", "-0.125", "1e-3", "abc"})
+
+	if got := l.TruncateFloats(2); !got.EqualSlice([]string{"3.14", "2.00", "-0.12", "0.00", "abc"}) {
+		t.Errorf("TruncateFloats(2) = %v", got)
+	}
+	if got := l.TruncateFloats(0); !got.EqualSlice([]string{"3", "2", "-0", "0", "abc"}) {
+		t.Errorf("TruncateFloats(0) = %v", got)
+	}
+}
+
+func TestList_ParseDurations(t *testing.T) {
+	ds, err := NewList([]string{"1s", "2m30s", "100ms"}).ParseDurations()
+	want := []time.Duration{time.Second, 150 * time.Second, 100 * time.Millisecond}
+	if err != nil || len(ds) != 3 || ds[0] != want[0] || ds[1] != want[1] || ds[2] != want[2] {
+		t.Errorf("ParseDurations() = %v, %v, want %v", ds, err, want)
+	}
+
+	ds, err = NewList([]string{"1s", "soon", "2s"}).ParseDurations()
+	if err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Errorf("ParseDurations() error = %v, want one naming element 1", err)
+	}
+	if len(ds) != 1 || ds[0] != time.Second {
+		t.Errorf("ParseDurations() partial result = %v, want [1s]", ds)
+	}
+}
+
+func TestList_ParseTimes(t *testing.T) {
+	ts, err := NewList([]string{"2021-03-04", "1999-12-31"}).ParseTimes("2006-01-02")
+	if err != nil || len(ts) != 2 || !ts[0].Equal(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)) || ts[1].Year() != 1999 {
+		t.Errorf("ParseTimes() = %v, %v", ts, err)
+	}
+
+	_, err = NewList([]string{"2021-03-04", "04/03/2021"}).ParseTimes("2006-01-02")
+	if err == nil || !strings.Contains(err.Error(), `element 1 "04/03/2021"`) {
+		t.Errorf("ParseTimes() error = %v, want one naming element 1 and its value", err)
+	}
+}
+
+func TestNewListFromDurations(t *testing.T) {
+	in := []time.Duration{time.Second, 150 * time.Second, 100 * time.Millisecond}
+	l := NewListFromDurations(in)
+
+	if !l.EqualSlice([]string{"1s", "2m30s", "100ms"}) {
+		t.Errorf("NewListFromDurations() = %v", l)
+	}
+	back, err := l.ParseDurations()
+	if err != nil || len(back) != 3 || back[1] != in[1] {
+		t.Errorf("ParseDurations() round trip = %v, %v", back, err)
+	}
+}
+
+func TestNewListFromInts(t *testing.T) {
+	in := []int{0, -7, 42, math.MaxInt32}
+	l := NewListFromInts(in)
+
+	if !l.EqualSlice([]string{"0", "-7", "42", "2147483647"}) {
+		t.Errorf("NewListFromInts() = %v", l)
+	}
+	if !Equal(l, NewList(in)) {
+		t.Errorf("NewListFromInts() = %v, NewList() = %v", l, NewList(in))
+	}
+}
+
+func TestNewListFromInt64s(t *testing.T) {
+	in := []int64{0, -1, math.MaxInt64, math.MinInt64}
+	l := NewListFromInt64s(in)
+
+	if !l.EqualSlice([]string{"0", "-1", "9223372036854775807", "-9223372036854775808"}) {
+		t.Errorf("NewListFromInt64s() = %v", l)
+	}
+	back := l.Int64()
+	for i := range in {
+		if back[i] != in[i] {
+			t.Errorf("Int64()[%d] = %d, want %d", i, back[i], in[i])
+		}
+	}
+}
+
+func TestList_Int64(t *testing.T) {
+	got := NewList([]string{"12", "x", "1.5", "9223372036854775808", "-3"}).Int64()
+	want := []int64{12, 0, 0, math.MaxInt64, -3}
+
+	if len(got) != len(want) {
+		t.Fatalf("Int64() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Int64()[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestList_ToInt64(t *testing.T) {
+	got, err := NewList([]string{"12", "-9223372036854775808"}).ToInt64()
+	if err != nil || len(got) != 2 || got[0] != 12 || got[1] != math.MinInt64 {
+		t.Errorf("ToInt64() = %v, %v", got, err)
+	}
+
+	cases := []struct {
+		in   []string
+		want error
+		msg  string
+	}{
+		{[]string{"1", "x"}, strconv.ErrSyntax, `element 1 "x"`},
+		{[]string{"1.5"}, strconv.ErrSyntax, `element 0 "1.5"`},
+		{[]string{"0", "0", "9223372036854775808"}, strconv.ErrRange, "element 2"},
+	}
+	for _, c := range cases {
+		_, err := NewList(c.in).ToInt64()
+		if !errors.Is(err, c.want) || !strings.Contains(err.Error(), c.msg) {
+			t.Errorf("ToInt64(%q) error = %v, want %v mentioning %s", c.in, err, c.want, c.msg)
+		}
+	}
+}
+
+func TestList_SafeInt(t *testing.T) {
+	got, err := NewList([]string{"12", "-3", "0"}).SafeInt()
+	if err != nil || len(got) != 3 || got[0] != 12 || got[1] != -3 {
+		t.Errorf("SafeInt() = %v, %v", got, err)
+	}
+
+	_, err = NewList([]string{"1", "2", "two"}).SafeInt()
+	if !errors.Is(err, strconv.ErrSyntax) || !strings.Contains(err.Error(), `element 2 "two"`) {
+		t.Errorf("SafeInt() with a word = %v", err)
+	}
+	if _, err := NewList([]string{" 1"}).SafeInt(); err == nil {
+		t.Errorf("SafeInt() accepted surrounding space")
+	}
+}
+
+func TestList_SafeBool(t *testing.T) {
+	got, err := NewList([]string{"true", "1", "YES", "False", "0", "no"}).SafeBool()
+	want := []bool{true, true, true, false, false, false}
+	if err != nil || len(got) != len(want) {
+		t.Fatalf("SafeBool() = %v, %v", got, err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("SafeBool()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	for _, bad := range []string{"", "t", "2", "on"} {
+		_, err := NewList([]string{"yes", bad}).SafeBool()
+		if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("element 1 %q", bad)) {
+			t.Errorf("SafeBool() with %q = %v", bad, err)
+		}
+	}
+}
+
+func TestList_Scan(t *testing.T) {
+	l := NewList([]string{"alice 30 admin", "bob 25 user"})
+
+	var names, roles []string
+	ages := []int{99}
+	if err := l.Scan("%s %d %s", &names, &ages, &roles); err != nil {
+		t.Fatal(err)
+	}
+	if !EqualSlices(names, []string{"alice", "bob"}) || !EqualSlices(roles, []string{"admin", "user"}) {
+		t.Errorf("Scan() names = %v, roles = %v", names, roles)
+	}
+	if len(ages) != 3 || ages[0] != 99 || ages[1] != 30 || ages[2] != 25 {
+		t.Errorf("Scan() ages = %v, want [99 30 25]", ages)
+	}
+
+	var ids []int
+	if err := NewList([]string{"id=4", "id=x"}).Scan("id=%d", &ids); err == nil || !strings.Contains(err.Error(), `element 1 "id=x"`) || ids != nil {
+		t.Errorf("Scan() with a bad element = %v, ids = %v", err, ids)
+	}
+	if err := l.Scan("%s", names); err == nil {
+		t.Errorf("Scan() into a slice value succeeded")
+	}
+	if err := l.Scan("%s %d", &names); err == nil {
+		t.Errorf("Scan() with fewer dests than verbs succeeded")
+	}
+}
+
+func TestList_ToFloat64(t *testing.T) {
+	got, err := NewList([]string{"1.5", "-2", "1e3"}).ToFloat64()
+	if err != nil || len(got) != 3 || got[0] != 1.5 || got[2] != 1000 {
+		t.Errorf("ToFloat64() = %v, %v", got, err)
+	}
+	if _, err := NewList([]string{"1", "", "x"}).ToFloat64(); err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Errorf("ToFloat64() with an empty element = %v", err)
+	}
+}
+
+func TestNewListFromFloat64s(t *testing.T) {
+	in := []float64{0, -1.5, 0.1, 1e21, 3}
+	l := NewListFromFloat64s(in)
+
+	if !l.EqualSlice([]string{"0", "-1.5", "0.1", "1000000000000000000000", "3"}) {
+		t.Errorf("NewListFromFloat64s() = %v", l)
+	}
+	if !Equal(l, NewList(in)) {
+		t.Errorf("NewListFromFloat64s() = %v, NewList() = %v", l, NewList(in))
+	}
+}
+
+func TestNewListFromStringer(t *testing.T) {
+	ip := net.IPv4(10, 0, 0, 1)
+	l := NewListFromStringer([]fmt.Stringer{ip, time.Minute, NewList([]int{1, 2})})
+
+	if !l.EqualSlice([]string{"10.0.0.1", "1m0s", "[1 2]"}) {
+		t.Errorf("NewListFromStringer() = %v", l)
+	}
+	if got := NewListFromStringer(nil); got.Length() != 0 {
+		t.Errorf("NewListFromStringer(nil) = %v", got)
+	}
+}
+
+func TestNewListFromTimes(t *testing.T) {
+	in := []time.Time{time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)}
+	l := NewListFromTimes(in, time.RFC3339)
+
+	if !l.EqualSlice([]string{"2021-03-04T05:06:07Z"}) {
+		t.Errorf("NewListFromTimes() = %v", l)
+	}
+	back, err := l.ParseTimes(time.RFC3339)
+	if err != nil || !back[0].Equal(in[0]) {
+		t.Errorf("ParseTimes() round trip = %v, %v", back, err)
+	}
+}
+
+func TestList_CachedLength(t *testing.T) {
+	for name, fn := range mutators {
+		l := NewList([]string{"a", "b", "c"})
+		l.CachedLength()
+		if err := callMutator(fn, l); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got, want := l.CachedLength(), l.Length(); got != want {
+			t.Errorf("after %s CachedLength() = %d, want %d", name, got, want)
+		}
+	}
+
+	// Concurrent readers must not race on the cache; run with -race.
+	l := benchmarkList(1000)
+	done := make(chan int)
+	for i := 0; i < 4; i++ {
+		go func() { done <- l.CachedLength() }()
+	}
+	for i := 0; i < 4; i++ {
+		if n := <-done; n != 1000 {
+			t.Errorf("concurrent CachedLength() = %d, want 1000", n)
+		}
+	}
+}
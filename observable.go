@@ -0,0 +1,42 @@
+package list
+
+// Observable wraps a List and calls onChange with a snapshot of the contents
+// after every Append, Pop, Remove or Insert made through it that changed the
+// list. Mutations made on the List directly are not observed; use OnChange
+// for that.
+type Observable struct {
+	List
+	onChange func(List)
+}
+
+// NewObservable wraps l.
+func NewObservable(l List, onChange func(List)) *Observable {
+	return &Observable{List: l, onChange: onChange}
+}
+
+func (o *Observable) Append(value interface{}) List {
+	return o.observe(func() { o.List.Append(value) })
+}
+
+func (o *Observable) Pop(idx int) List {
+	return o.observe(func() { o.List.Pop(idx) })
+}
+
+func (o *Observable) Remove(value interface{}) List {
+	return o.observe(func() { o.List.Remove(value) })
+}
+
+func (o *Observable) Insert(idx int, value interface{}) List {
+	return o.observe(func() { o.List.Insert(idx, value) })
+}
+
+// observe runs mutate and reports the result if the length changed; all
+// four wrapped operations either change the length or do nothing.
+func (o *Observable) observe(mutate func()) List {
+	n := o.List.Length()
+	mutate()
+	if o.List.Length() != n && o.onChange != nil {
+		o.onChange(o.List.Copy())
+	}
+	return o.List
+}
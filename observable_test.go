@@ -0,0 +1,44 @@
+package list
+
+import "testing"
+
+func TestNewObservable(t *testing.T) {
+	var snapshots []List
+	o := NewObservable(NewList([]string{"a"}), func(l List) { snapshots = append(snapshots, l) })
+
+	o.Append("b")
+	o.Insert(0, "z")
+	o.Pop(-1)
+	o.Remove("z")
+
+	want := [][]string{{"a", "b"}, {"z", "a", "b"}, {"z", "a"}, {"a"}}
+	if len(snapshots) != len(want) {
+		t.Fatalf("onChange fired %d times, want %d: %v", len(snapshots), len(want), snapshots)
+	}
+	for i, w := range want {
+		if !snapshots[i].EqualSlice(w) {
+			t.Errorf("snapshot %d = %v, want %v", i, snapshots[i], w)
+		}
+	}
+
+	// Snapshots are copies and do not follow later changes.
+	o.Append("c")
+	if !snapshots[0].EqualSlice([]string{"a", "b"}) {
+		t.Errorf("snapshot changed to %v", snapshots[0])
+	}
+}
+
+func TestObservable_noop(t *testing.T) {
+	calls := 0
+	o := NewObservable(NewList([]string{"a"}), func(List) { calls++ })
+
+	o.Pop(5)
+	o.Remove("missing")
+	o.Insert(9, "x")
+	if calls != 0 {
+		t.Errorf("onChange fired %d times for no-op mutations", calls)
+	}
+	if got := o.Length(); got != 1 {
+		t.Errorf("embedded Length() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,264 @@
+package list
+
+import (
+	"container/heap"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompareMode selects how a SortKey compares extracted keys.
+type CompareMode int
+
+const (
+	// Lexicographic compares keys byte by byte.
+	Lexicographic CompareMode = iota
+	// Numeric compares keys as floats; keys that do not parse sort after
+	// every number, lexicographically among themselves.
+	Numeric
+	// Natural compares runs of digits by value, so "a2" sorts before "a10".
+	Natural
+)
+
+// SortKey is one level of a multi-key sort.
+type SortKey struct {
+	// Extract derives the key from an element; nil uses the element itself.
+	Extract func(string) string
+	Mode    CompareMode
+	Desc    bool
+}
+
+// SortByKeys sorts the receiver in place by keys in priority order. The sort
+// is stable, so elements equal on every key keep their relative order.
+func (d List) SortByKeys(keys ...SortKey) List {
+	d.guard()
+	v := *d.value
+	sort.SliceStable(v, func(i, j int) bool {
+		for _, k := range keys {
+			if c := k.compare(v[i], v[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	d.notify(OpSort, -1, "")
+	return d
+}
+
+// SortedByKeys is SortByKeys on a copy; the receiver is untouched.
+func (d List) SortedByKeys(keys ...SortKey) List {
+	return d.Copy().SortByKeys(keys...)
+}
+
+func (k SortKey) compare(a, b string) int {
+	if k.Extract != nil {
+		a, b = k.Extract(a), k.Extract(b)
+	}
+
+	var c int
+	switch k.Mode {
+	case Numeric:
+		c = compareNumeric(a, b)
+	case Natural:
+		c = compareNatural(a, b)
+	default:
+		c = strings.Compare(a, b)
+	}
+	if k.Desc {
+		return -c
+	}
+	return c
+}
+
+func compareNumeric(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	case fa < fb:
+		return -1
+	case fa > fb:
+		return 1
+	}
+	return 0
+}
+
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		da, db := digitPrefix(a), digitPrefix(b)
+		if da == "" || db == "" {
+			if a[0] != b[0] {
+				return strings.Compare(a[:1], b[:1])
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+		if len(na) != len(nb) {
+			if len(na) < len(nb) {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(na, nb); c != 0 {
+			return c
+		}
+		a, b = a[len(da):], b[len(db):]
+	}
+	return strings.Compare(a, b)
+}
+
+func digitPrefix(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+// SortBy returns a copy stably sorted by the string key field derives from
+// each element, e.g. SortBy(strings.ToLower) for a case-insensitive order.
+func (d List) SortBy(field func(string) string) List {
+	return d.SortedByKeyCached(field)
+}
+
+// SortByKeyCached sorts the receiver in place by key, calling key exactly once
+// per element (decorate-sort-undecorate). Use it when key is expensive. The
+// sort is stable.
+func (d List) SortByKeyCached(key func(string) string) List {
+	v := *d.value
+	keys := make([]string, len(v))
+	for i, item := range v {
+		keys[i] = key(item)
+	}
+	return d.applyOrder(func(a, b int) bool { return keys[a] < keys[b] })
+}
+
+// SortByKeyCachedNumeric is SortByKeyCached with a numeric key, ascending.
+func (d List) SortByKeyCachedNumeric(key func(string) float64) List {
+	v := *d.value
+	keys := make([]float64, len(v))
+	for i, item := range v {
+		keys[i] = key(item)
+	}
+	return d.applyOrder(func(a, b int) bool { return keys[a] < keys[b] })
+}
+
+// SortedByKeyCached is SortByKeyCached on a copy; the receiver is untouched.
+func (d List) SortedByKeyCached(key func(string) string) List {
+	return d.Copy().SortByKeyCached(key)
+}
+
+// SortByFrequency returns a copy sorted so the most frequent elements come
+// first; the receiver is untouched. Equal elements stay together, and
+// elements with equal counts keep the order of their first occurrence.
+func (d List) SortByFrequency() List {
+	return d.Copy().sortByFrequency(true)
+}
+
+// SortByFrequencyAsc is SortByFrequency with the least frequent elements first.
+func (d List) SortByFrequencyAsc() List {
+	return d.Copy().sortByFrequency(false)
+}
+
+// sortByFrequency sorts the receiver in place.
+func (d List) sortByFrequency(desc bool) List {
+	v := *d.value
+	freq := d.Frequency()
+	first := make(map[string]int, len(freq))
+	for i := len(v) - 1; i >= 0; i-- {
+		first[v[i]] = i
+	}
+	return d.applyOrder(func(a, b int) bool {
+		fa, fb := freq[v[a]], freq[v[b]]
+		if fa != fb {
+			return fa > fb == desc
+		}
+		return first[v[a]] < first[v[b]]
+	})
+}
+
+// applyOrder stably sorts the receiver by less, which compares original indexes.
+func (d List) applyOrder(less func(a, b int) bool) List {
+	d.guard()
+	v := *d.value
+	perm := make([]int, len(v))
+	for i := range perm {
+		perm[i] = i
+	}
+	sort.SliceStable(perm, func(i, j int) bool { return less(perm[i], perm[j]) })
+
+	sorted := make([]string, len(v))
+	for i, j := range perm {
+		sorted[i] = v[j]
+	}
+	copy(v, sorted)
+	d.notify(OpSort, -1, "")
+	return d
+}
+
+// MergeAll merges lists that are each sorted lexicographically into one
+// sorted list in O(N log k) for N elements across k lists. Equal elements
+// keep the order of the lists they came from.
+func MergeAll(lists ...List) List {
+	h := make(mergeHeap, 0, len(lists))
+	n := 0
+	for i, l := range lists {
+		v := l.slice()
+		n += len(v)
+		if len(v) > 0 {
+			h = append(h, mergeCursor{src: v, list: i})
+		}
+	}
+	heap.Init(&h)
+
+	val := make([]string, 0, n)
+	for len(h) > 0 {
+		c := &h[0]
+		val = append(val, c.src[c.pos])
+		c.pos++
+		if c.pos == len(c.src) {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return newList(val)
+}
+
+// mergeCursor is the read position in one input of MergeAll.
+type mergeCursor struct {
+	src  []string
+	pos  int
+	list int
+}
+
+// mergeHeap orders cursors by their current element, then by input index.
+type mergeHeap []mergeCursor
+
+func (h mergeHeap) Len() int { return len(h) }
+
+func (h mergeHeap) Less(i, j int) bool {
+	a, b := h[i].src[h[i].pos], h[j].src[h[j].pos]
+	if a != b {
+		return a < b
+	}
+	return h[i].list < h[j].list
+}
+
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *mergeHeap) Push(x interface{}) { *h = append(*h, x.(mergeCursor)) }
+
+func (h *mergeHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
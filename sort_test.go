@@ -0,0 +1,220 @@
+package list
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func field(n int) func(string) string {
+	return func(s string) string { return strings.Split(s, "|")[n] }
+}
+
+func TestList_SortByKeys(t *testing.T) {
+	l := NewList([]string{
+		"us|prod|web10",
+		"eu|dev|api",
+		"us|prod|web2",
+		"eu|prod|api",
+		"us|dev|web1",
+		"us|prod|web1",
+	})
+	l.SortByKeys(
+		SortKey{Extract: field(0)},
+		SortKey{Extract: field(1), Desc: true},
+		SortKey{Extract: field(2), Mode: Natural},
+	)
+
+	want := []string{
+		"eu|prod|api",
+		"eu|dev|api",
+		"us|prod|web1",
+		"us|prod|web2",
+		"us|prod|web10",
+		"us|dev|web1",
+	}
+	if !l.EqualSlice(want) {
+		t.Errorf("SortByKeys() = %v, want %v", l, want)
+	}
+}
+
+func TestList_SortByKeysStable(t *testing.T) {
+	l := NewList([]string{"b1", "a1", "b2", "a2", "b3"})
+	l.SortByKeys(SortKey{Extract: func(s string) string { return s[:1] }})
+
+	if !l.EqualSlice([]string{"a1", "a2", "b1", "b2", "b3"}) {
+		t.Errorf("SortByKeys() is not stable: %v", l)
+	}
+}
+
+func TestList_SortedByKeys(t *testing.T) {
+	l := NewList([]string{"10", "x", "9", "-1.5", "a"})
+	got := l.SortedByKeys(SortKey{Mode: Numeric})
+
+	if !got.EqualSlice([]string{"-1.5", "9", "10", "a", "x"}) {
+		t.Errorf("SortedByKeys(Numeric) = %v, want [-1.5 9 10 a x]", got)
+	}
+	if !l.EqualSlice([]string{"10", "x", "9", "-1.5", "a"}) {
+		t.Errorf("SortedByKeys() changed the receiver to %v", l)
+	}
+	if got := l.SortedByKeys(SortKey{Mode: Numeric, Desc: true}); !got.EqualSlice([]string{"x", "a", "10", "9", "-1.5"}) {
+		t.Errorf("SortedByKeys(Numeric, Desc) = %v", got)
+	}
+}
+
+func TestCompareNatural(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"a2", "a10", -1},
+		{"a10", "a2", 1},
+		{"a02", "a2", 0},
+		{"file1.txt", "file1.txt", 0},
+		{"x9y", "x9z", -1},
+		{"abc", "ab", 1},
+		{"1", "a", -1},
+	}
+	for _, c := range cases {
+		if got := compareNatural(c.a, c.b); got != c.want {
+			t.Errorf("compareNatural(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestList_SortByKeyCached(t *testing.T) {
+	l := NewList([]string{"id=3", "id=1", "id=2", "id=1b"})
+	calls := 0
+	l.SortByKeyCached(func(s string) string {
+		calls++
+		return strings.TrimPrefix(s, "id=")
+	})
+
+	if !l.EqualSlice([]string{"id=1", "id=1b", "id=2", "id=3"}) {
+		t.Errorf("SortByKeyCached() = %v", l)
+	}
+	if calls != 4 {
+		t.Errorf("key called %d times, want 4", calls)
+	}
+}
+
+func TestList_SortByKeyCachedNumeric(t *testing.T) {
+	l := NewList([]string{"b:10", "a:9", "c:10", "d:-1"})
+	l.SortByKeyCachedNumeric(func(s string) float64 {
+		f, _ := strconv.ParseFloat(strings.SplitN(s, ":", 2)[1], 64)
+		return f
+	})
+
+	// b and c tie on 10 and keep their original order.
+	if !l.EqualSlice([]string{"d:-1", "a:9", "b:10", "c:10"}) {
+		t.Errorf("SortByKeyCachedNumeric() = %v", l)
+	}
+}
+
+func TestList_SortedByKeyCached(t *testing.T) {
+	l := NewList([]string{"B", "a", "C"})
+	got := l.SortedByKeyCached(strings.ToLower)
+
+	if !got.EqualSlice([]string{"a", "B", "C"}) {
+		t.Errorf("SortedByKeyCached() = %v, want [a B C]", got)
+	}
+	if !l.EqualSlice([]string{"B", "a", "C"}) {
+		t.Errorf("SortedByKeyCached() changed the receiver to %v", l)
+	}
+}
+
+// slowKey stands in for an expensive extraction such as timestamp parsing.
+func slowKey(s string) string {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func timestamps(n int) List {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	val := make([]string, n)
+	for i := range val {
+		val[i] = base.Add(time.Duration((i*7919)%n) * time.Second).Format(time.RFC3339)
+	}
+	return NewList(val)
+}
+
+func BenchmarkList_SortByKeys(b *testing.B) {
+	l := timestamps(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.SortedByKeys(SortKey{Extract: slowKey})
+	}
+}
+
+func BenchmarkList_SortByKeyCached(b *testing.B) {
+	l := timestamps(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.SortedByKeyCached(slowKey)
+	}
+}
+
+func TestList_SortBy(t *testing.T) {
+	l := NewList([]string{"banana", "Apple", "cherry", "apple"})
+	got := l.SortBy(strings.ToLower)
+
+	// "Apple" and "apple" share a key and keep their original order.
+	if !got.EqualSlice([]string{"Apple", "apple", "banana", "cherry"}) {
+		t.Errorf("SortBy(strings.ToLower) = %v", got)
+	}
+	if !l.EqualSlice([]string{"banana", "Apple", "cherry", "apple"}) {
+		t.Errorf("SortBy() changed the receiver to %v", l)
+	}
+}
+
+func TestMergeAll(t *testing.T) {
+	a := NewList([]string{"a", "d", "g"})
+	b := NewList([]string{"b", "d", "h", "i"})
+	c := NewList([]string{"c"})
+	var zero List
+
+	got := MergeAll(a, NilList(nil), b, zero, c)
+	if !got.EqualSlice([]string{"a", "b", "c", "d", "d", "g", "h", "i"}) {
+		t.Errorf("MergeAll() = %v", got)
+	}
+	if got := MergeAll(); got.Length() != 0 {
+		t.Errorf("MergeAll() of nothing = %v", got)
+	}
+
+	var parts []List
+	var all []string
+	for i := 0; i < 20; i++ {
+		val := make([]string, 0, 50)
+		for j := 0; j < 50; j++ {
+			val = append(val, fmt.Sprintf("%04d", (j*37+i*11)%1000))
+		}
+		sort.Strings(val)
+		parts = append(parts, NewList(val))
+		all = append(all, val...)
+	}
+	sort.Strings(all)
+	if !MergeAll(parts...).EqualSlice(all) {
+		t.Errorf("MergeAll() of 20 lists is not the sorted union")
+	}
+}
+
+func TestList_SortByFrequency(t *testing.T) {
+	l := NewList([]string{"c", "a", "b", "a", "c", "a", "d", "b"})
+
+	if got := l.SortByFrequency(); !got.EqualSlice([]string{"a", "a", "a", "c", "c", "b", "b", "d"}) {
+		t.Errorf("SortByFrequency() = %v", got)
+	}
+	if got := l.SortByFrequencyAsc(); !got.EqualSlice([]string{"d", "c", "c", "b", "b", "a", "a", "a"}) {
+		t.Errorf("SortByFrequencyAsc() = %v", got)
+	}
+
+	if !l.EqualSlice([]string{"c", "a", "b", "a", "c", "a", "d", "b"}) {
+		t.Errorf("SortByFrequency() changed the receiver to %v", l)
+	}
+	if got := l.Freeze().SortByFrequency(); got.Length() != 8 {
+		t.Errorf("SortByFrequency() of a frozen list = %v", got)
+	}
+}
@@ -0,0 +1,88 @@
+package list
+
+// Stream is a pipeline over a List. Its stages are fused and only run, in a
+// single pass, when a terminal operation (Collect, Count, First, ForEach) is called.
+type Stream struct {
+	src LazyList
+}
+
+// Stream starts a pipeline over the list's elements.
+func (d List) Stream() *Stream {
+	return &Stream{src: Lazy(d)}
+}
+
+func (s *Stream) Filter(pred func(string) bool) *Stream {
+	return &Stream{src: s.src.Filter(pred)}
+}
+
+func (s *Stream) Map(fn func(string) string) *Stream {
+	return &Stream{src: s.src.Map(fn)}
+}
+
+// FlatMap replaces every element with the elements returned by fn.
+func (s *Stream) FlatMap(fn func(string) []string) *Stream {
+	src := s.src
+	return &Stream{src: NewLazyList(func(yield func(string) bool) {
+		src.Each(func(v string) bool {
+			for _, item := range fn(v) {
+				if !yield(item) {
+					return false
+				}
+			}
+			return true
+		})
+	})}
+}
+
+// Take passes on at most n elements and then stops the scan.
+func (s *Stream) Take(n int) *Stream {
+	src := s.src
+	return &Stream{src: NewLazyList(func(yield func(string) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		src.Each(func(v string) bool {
+			taken++
+			return yield(v) && taken < n
+		})
+	})}
+}
+
+// Skip drops the first n elements.
+func (s *Stream) Skip(n int) *Stream {
+	src := s.src
+	return &Stream{src: NewLazyList(func(yield func(string) bool) {
+		skipped := 0
+		src.Each(func(v string) bool {
+			if skipped < n {
+				skipped++
+				return true
+			}
+			return yield(v)
+		})
+	})}
+}
+
+// Collect runs the pipeline and returns its output as a new List.
+func (s *Stream) Collect() List {
+	return s.src.Materialize()
+}
+
+// Count runs the pipeline and returns the number of elements it produced.
+func (s *Stream) Count() int {
+	return s.src.Length()
+}
+
+// First runs the pipeline until it produces one element.
+func (s *Stream) First() (string, bool) {
+	return s.src.First()
+}
+
+// ForEach runs the pipeline and calls fn for every element it produces.
+func (s *Stream) ForEach(fn func(string)) {
+	s.src.Each(func(v string) bool {
+		fn(v)
+		return true
+	})
+}
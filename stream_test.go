@@ -0,0 +1,111 @@
+package list
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestList_Stream(t *testing.T) {
+	l := NewList([]string{"1", "2", "3", "4", "5", "6"})
+	even := func(s string) bool { n, _ := strconv.Atoi(s); return n%2 == 0 }
+	square := func(s string) string { n, _ := strconv.Atoi(s); return strconv.Itoa(n * n) }
+
+	got := l.Stream().Filter(even).Map(square).Collect()
+	if !got.EqualSlice([]string{"4", "16", "36"}) {
+		t.Errorf("Filter().Map() = %v, want [4 16 36]", got)
+	}
+
+	// Stage order matters: squaring first keeps only the even squares.
+	got = l.Stream().Map(square).Filter(even).Collect()
+	if !got.EqualSlice([]string{"4", "16", "36"}) {
+		t.Errorf("Map().Filter() = %v, want [4 16 36]", got)
+	}
+	got = l.Stream().Skip(1).Take(3).Skip(1).Collect()
+	if !got.EqualSlice([]string{"3", "4"}) {
+		t.Errorf("Skip(1).Take(3).Skip(1) = %v, want [3 4]", got)
+	}
+}
+
+func TestStream_FlatMap(t *testing.T) {
+	got := NewList([]string{"a b", "", "c"}).Stream().FlatMap(strings.Fields).Collect()
+
+	if !got.EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("FlatMap() = %v, want [a b c]", got)
+	}
+}
+
+func TestStream_Take(t *testing.T) {
+	scanned := 0
+	l := NewList([]string{"a", "b", "c", "d"})
+	got := l.Stream().Map(func(s string) string { scanned++; return s }).Take(2).Collect()
+
+	if !got.EqualSlice([]string{"a", "b"}) {
+		t.Errorf("Take(2) = %v, want [a b]", got)
+	}
+	if scanned != 2 {
+		t.Errorf("Take(2) scanned %d elements, want 2", scanned)
+	}
+	if n := l.Stream().Take(0).Count(); n != 0 {
+		t.Errorf("Take(0).Count() = %d, want 0", n)
+	}
+}
+
+func TestStream_First(t *testing.T) {
+	scanned := 0
+	l := NewList([]string{"a", "bb", "cc", "d"})
+	v, ok := l.Stream().Filter(func(s string) bool { scanned++; return len(s) == 2 }).First()
+
+	if !ok || v != "bb" {
+		t.Errorf("First() = (%q, %v), want (\"bb\", true)", v, ok)
+	}
+	if scanned != 2 {
+		t.Errorf("First() scanned %d elements, want 2", scanned)
+	}
+	if _, ok := NilList(nil).Stream().First(); ok {
+		t.Errorf("First() on empty stream returned ok = true")
+	}
+}
+
+func TestStream_Count(t *testing.T) {
+	l := NewList([]string{"a", "bb", "cc"})
+	s := l.Stream().Filter(func(s string) bool { return len(s) == 2 })
+
+	if n := s.Count(); n != 2 {
+		t.Errorf("Count() = %d, want 2", n)
+	}
+	if n := s.Count(); n != 2 {
+		t.Errorf("second Count() = %d, want 2", n)
+	}
+}
+
+func TestStream_ForEach(t *testing.T) {
+	var got []string
+	NewList([]string{"a", "b"}).Stream().Map(strings.ToUpper).ForEach(func(s string) {
+		got = append(got, s)
+	})
+
+	if !NewList(got).EqualSlice([]string{"A", "B"}) {
+		t.Errorf("ForEach() saw %v, want [A B]", got)
+	}
+}
+
+func BenchmarkList_FilterMapEager(b *testing.B) {
+	l := benchmarkList(1000000)
+	keep := func(s string) bool { return s[len(s)-1] == '7' }
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Filter(keep).Map(strings.ToUpper).Filter(keep).Length()
+	}
+}
+
+func BenchmarkStream_FilterMap(b *testing.B) {
+	l := benchmarkList(1000000)
+	keep := func(s string) bool { return s[len(s)-1] == '7' }
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Stream().Filter(keep).Map(strings.ToUpper).Filter(keep).Count()
+	}
+}
@@ -0,0 +1,51 @@
+package list
+
+// Versioned keeps a linear history of committed states of a List with undo
+// and redo. Edit the list returned by Current and call Commit to record it.
+type Versioned struct {
+	history []List
+	cursor  int
+	current List
+}
+
+// NewVersioned starts a history whose first version is l's current contents.
+func NewVersioned(l List) *Versioned {
+	return &Versioned{history: []List{l.Copy()}, current: l}
+}
+
+// Current returns the working list. It is the same List across Undo and
+// Redo, which replace its contents.
+func (v *Versioned) Current() List {
+	return v.current
+}
+
+// Commit records the working list as a new version, discarding any versions
+// that could have been redone.
+func (v *Versioned) Commit() *Versioned {
+	v.history = append(v.history[:v.cursor+1], v.current.Copy())
+	v.cursor++
+	return v
+}
+
+// Undo restores the previous committed version, dropping uncommitted edits.
+// It reports false if there is nothing to undo or the list is frozen.
+func (v *Versioned) Undo() bool {
+	return v.move(-1)
+}
+
+// Redo restores the version undone most recently.
+func (v *Versioned) Redo() bool {
+	return v.move(1)
+}
+
+func (v *Versioned) move(delta int) bool {
+	to := v.cursor + delta
+	if to < 0 || to >= len(v.history) {
+		return false
+	}
+	if err := v.current.replace(*v.history[to].value); err != nil {
+		return false
+	}
+	v.cursor = to
+	return true
+}
@@ -0,0 +1,71 @@
+package list
+
+import "testing"
+
+func TestNewVersioned(t *testing.T) {
+	v := NewVersioned(NewList([]string{"a"}))
+	cur := v.Current()
+
+	cur.Append("b")
+	v.Commit()
+	cur.Append("c")
+	v.Commit()
+
+	steps := []struct {
+		op   func() bool
+		ok   bool
+		want []string
+	}{
+		{v.Undo, true, []string{"a", "b"}},
+		{v.Undo, true, []string{"a"}},
+		{v.Undo, false, []string{"a"}},
+		{v.Redo, true, []string{"a", "b"}},
+		{v.Redo, true, []string{"a", "b", "c"}},
+		{v.Redo, false, []string{"a", "b", "c"}},
+	}
+	for i, s := range steps {
+		if ok := s.op(); ok != s.ok || !v.Current().EqualSlice(s.want) {
+			t.Errorf("step %d: got (%v, %v), want (%v, %v)", i, ok, v.Current(), s.ok, s.want)
+		}
+	}
+	if !cur.EqualSlice([]string{"a", "b", "c"}) {
+		t.Errorf("list handle held by the caller = %v, want [a b c]", cur)
+	}
+}
+
+func TestVersioned_Commit(t *testing.T) {
+	v := NewVersioned(NewList([]string{"a"}))
+	v.Current().Append("b")
+	v.Commit()
+	v.Undo()
+
+	// Committing after an undo discards the redo branch.
+	v.Current().Append("x")
+	v.Commit()
+	if v.Redo() {
+		t.Errorf("Redo() after a new commit succeeded")
+	}
+	if !v.Undo() || !v.Current().EqualSlice([]string{"a"}) {
+		t.Errorf("Undo() = %v, want [a]", v.Current())
+	}
+	if !v.Redo() || !v.Current().EqualSlice([]string{"a", "x"}) {
+		t.Errorf("Redo() = %v, want [a x]", v.Current())
+	}
+}
+
+func TestVersioned_Undo(t *testing.T) {
+	v := NewVersioned(NewList([]string{"a"}))
+	v.Current().Append("b")
+	v.Commit()
+
+	// Uncommitted edits are dropped by Undo.
+	v.Current().Append("uncommitted")
+	if !v.Undo() || !v.Current().EqualSlice([]string{"a"}) {
+		t.Errorf("Undo() = %v, want [a]", v.Current())
+	}
+
+	v.Current().Freeze()
+	if v.Redo() {
+		t.Errorf("Redo() on a frozen list succeeded")
+	}
+}